@@ -0,0 +1,295 @@
+// Package analytics computes metrics over the history of trending results.
+//
+// The history is a list of snapshots, usually read from a store.Store:
+//
+//	snapshots, err := s.Snapshots(store.Filter{Kind: store.KindProjects, Since: trending.TimeToday})
+//	...
+//	for _, p := range analytics.Projects(snapshots, analytics.Options{}) {
+//		fmt.Printf("%s: %d days on trending, best rank %d, momentum %.1f\n", p.Project.Name, p.DaysOnTrending, p.BestRank, p.Momentum)
+//	}
+//
+// Snapshots are grouped by day (UTC). Multiple snapshots of the same day count once,
+// with the best rank and the most stars of that day.
+// The stars of a project are the stars gained within the period of the query (trending.Project.PeriodStars),
+// so the history should contain snapshots of a single period (see store.Filter.Since).
+//
+// All metrics are deterministic: they only depend on the snapshots and the Options.
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+	"github.com/andygrunwald/go-trending/store"
+)
+
+// DefaultHalfLife is the default of Options.HalfLife.
+const DefaultHalfLife = 7 * 24 * time.Hour
+
+// pageSize is the number of entries of a trending page. It is the worst rank used to weight the momentum.
+const pageSize = 25
+
+// Options configures the computation of the momentum of projects.
+type Options struct {
+	// Now is the reference time of the momentum. Only the day (UTC) is relevant.
+	// If zero, the time of the latest snapshot is used, so the result only depends on the snapshots.
+	Now time.Time
+
+	// HalfLife is the age after which a day on trending contributes half to the momentum.
+	// If zero, DefaultHalfLife is used.
+	HalfLife time.Duration
+}
+
+// ProjectStats are the metrics of a single repository.
+type ProjectStats struct {
+	// Project is the latest appearance of the repository.
+	Project trending.Project `json:"project"`
+
+	// FirstSeen is the time of the first snapshot the repository is part of.
+	FirstSeen time.Time `json:"first_seen"`
+
+	// LastSeen is the time of the latest snapshot the repository is part of.
+	LastSeen time.Time `json:"last_seen"`
+
+	// Appearances is the number of snapshots the repository is part of.
+	Appearances int `json:"appearances"`
+
+	// DaysOnTrending is the number of days (UTC) the repository was trending.
+	DaysOnTrending int `json:"days_on_trending"`
+
+	// BestRank is the best (lowest) 1-based rank of the repository.
+	BestRank int `json:"best_rank"`
+
+	// TotalStars is the sum of the period stars of every day on trending.
+	TotalStars int `json:"total_stars"`
+
+	// Velocity is the number of stars per day between the first and the last day on trending (both inclusive).
+	// Days in between without an appearance count with zero stars.
+	Velocity float64 `json:"velocity"`
+
+	// Momentum is a composite score of stars, rank and recency.
+	// Every day on trending contributes its stars, weighted by the rank (rank 1 counts fully, rank 25 with 1/25)
+	// and halved for every Options.HalfLife of age. Recent climbers score higher than projects that trended long ago.
+	Momentum float64 `json:"momentum"`
+}
+
+// DeveloperStats are the metrics of a single developer.
+type DeveloperStats struct {
+	// Developer is the latest appearance of the developer.
+	Developer trending.Developer `json:"developer"`
+
+	// FirstSeen is the time of the first snapshot the developer is part of.
+	FirstSeen time.Time `json:"first_seen"`
+
+	// LastSeen is the time of the latest snapshot the developer is part of.
+	LastSeen time.Time `json:"last_seen"`
+
+	// Appearances is the number of snapshots the developer is part of.
+	Appearances int `json:"appearances"`
+
+	// DaysOnTrending is the number of days (UTC) the developer was trending.
+	DaysOnTrending int `json:"days_on_trending"`
+
+	// BestRank is the best (lowest) 1-based rank of the developer.
+	BestRank int `json:"best_rank"`
+
+	// Languages are the languages (Language.URLName) of the queries the developer was trending for, sorted.
+	// Snapshots of all languages don't add a language.
+	Languages []string `json:"languages"`
+}
+
+// day is the aggregated appearance of an entry on a single day.
+type day struct {
+	rank  int
+	stars int
+}
+
+// Projects computes the metrics of every repository of the project snapshots.
+// Snapshots of developers are ignored.
+// The result is ordered by Momentum, TotalStars (both descending) and name.
+func Projects(snapshots []store.Snapshot, opts Options) []ProjectStats {
+	now, halfLife := opts.defaults(snapshots)
+
+	stats := make(map[string]*ProjectStats)
+	days := make(map[string]map[time.Time]day)
+	for _, s := range sortedSnapshots(snapshots, store.KindProjects) {
+		for i, p := range s.Projects {
+			key := strings.ToLower(p.Name)
+			ps, ok := stats[key]
+			if !ok {
+				ps = &ProjectStats{FirstSeen: s.FetchedAt, BestRank: i + 1}
+				stats[key] = ps
+				days[key] = make(map[time.Time]day)
+			}
+			ps.Project = p
+			ps.LastSeen = s.FetchedAt
+			ps.Appearances++
+			ps.BestRank = min(ps.BestRank, i+1)
+			addDay(days[key], s.FetchedAt, i+1, p.PeriodStars)
+		}
+	}
+
+	result := make([]ProjectStats, 0, len(stats))
+	for key, ps := range stats {
+		ps.DaysOnTrending = len(days[key])
+		// Sum in the order of the days, floating point additions depend on the order
+		for _, d := range sortedDays(days[key]) {
+			v := days[key][d]
+			ps.TotalStars += v.stars
+			ps.Momentum += float64(v.stars) * rankWeight(v.rank) * decay(truncateDay(now).Sub(d), halfLife)
+		}
+		span := truncateDay(ps.LastSeen).Sub(truncateDay(ps.FirstSeen))
+		ps.Velocity = float64(ps.TotalStars) / (span.Hours()/24 + 1)
+		result = append(result, *ps)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Momentum != b.Momentum {
+			return a.Momentum > b.Momentum
+		}
+		if a.TotalStars != b.TotalStars {
+			return a.TotalStars > b.TotalStars
+		}
+		return strings.ToLower(a.Project.Name) < strings.ToLower(b.Project.Name)
+	})
+	return result
+}
+
+// Developers computes the metrics of every developer of the developer snapshots.
+// Snapshots of projects are ignored.
+// The result is ordered by DaysOnTrending, Appearances (both descending), BestRank and name.
+func Developers(snapshots []store.Snapshot) []DeveloperStats {
+	stats := make(map[string]*DeveloperStats)
+	days := make(map[string]map[time.Time]day)
+	languages := make(map[string]map[string]bool)
+	for _, s := range sortedSnapshots(snapshots, store.KindDevelopers) {
+		for i, d := range s.Developers {
+			key := shared.DeveloperKey(d)
+			ds, ok := stats[key]
+			if !ok {
+				ds = &DeveloperStats{FirstSeen: s.FetchedAt, BestRank: i + 1}
+				stats[key] = ds
+				days[key] = make(map[time.Time]day)
+				languages[key] = make(map[string]bool)
+			}
+			ds.Developer = d
+			ds.LastSeen = s.FetchedAt
+			ds.Appearances++
+			ds.BestRank = min(ds.BestRank, i+1)
+			addDay(days[key], s.FetchedAt, i+1, 0)
+			if len(s.Query.Language) > 0 {
+				languages[key][s.Query.Language] = true
+			}
+		}
+	}
+
+	result := make([]DeveloperStats, 0, len(stats))
+	for key, ds := range stats {
+		ds.DaysOnTrending = len(days[key])
+		ds.Languages = make([]string, 0, len(languages[key]))
+		for l := range languages[key] {
+			ds.Languages = append(ds.Languages, l)
+		}
+		sort.Strings(ds.Languages)
+		result = append(result, *ds)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.DaysOnTrending != b.DaysOnTrending {
+			return a.DaysOnTrending > b.DaysOnTrending
+		}
+		if a.Appearances != b.Appearances {
+			return a.Appearances > b.Appearances
+		}
+		if a.BestRank != b.BestRank {
+			return a.BestRank < b.BestRank
+		}
+		return strings.ToLower(a.Developer.DisplayName) < strings.ToLower(b.Developer.DisplayName)
+	})
+	return result
+}
+
+// defaults returns the reference time and the half-life of the momentum.
+func (o Options) defaults(snapshots []store.Snapshot) (time.Time, time.Duration) {
+	now := o.Now
+	if now.IsZero() {
+		for _, s := range snapshots {
+			if s.FetchedAt.After(now) {
+				now = s.FetchedAt
+			}
+		}
+	}
+
+	halfLife := o.HalfLife
+	if halfLife <= 0 {
+		halfLife = DefaultHalfLife
+	}
+	return now, halfLife
+}
+
+// sortedSnapshots returns the snapshots of kind ordered by FetchedAt (oldest first).
+// Stores already return this order, but the snapshots might be collected from elsewhere.
+func sortedSnapshots(snapshots []store.Snapshot, kind string) []store.Snapshot {
+	result := make([]store.Snapshot, 0, len(snapshots))
+	for _, s := range snapshots {
+		if s.Kind == kind {
+			result = append(result, s)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].FetchedAt.Before(result[j].FetchedAt)
+	})
+	return result
+}
+
+// addDay records an appearance with rank and stars at the day of t.
+// The best rank and the most stars of a day are kept.
+func addDay(days map[time.Time]day, t time.Time, rank, stars int) {
+	d := truncateDay(t)
+	v, ok := days[d]
+	if !ok {
+		days[d] = day{rank: rank, stars: stars}
+		return
+	}
+	days[d] = day{rank: min(v.rank, rank), stars: max(v.stars, stars)}
+}
+
+// sortedDays returns the days of days in chronological order.
+func sortedDays(days map[time.Time]day) []time.Time {
+	result := make([]time.Time, 0, len(days))
+	for d := range days {
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Before(result[j])
+	})
+	return result
+}
+
+// truncateDay returns midnight UTC of the day of t.
+func truncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// rankWeight returns the weight of rank for the momentum: 1 for rank 1 down to 1/pageSize for rank pageSize.
+func rankWeight(rank int) float64 {
+	if rank > pageSize {
+		rank = pageSize
+	}
+	return float64(pageSize+1-rank) / pageSize
+}
+
+// decay returns the factor of an appearance that is age old: 1 for now, 0.5 after halfLife and so on.
+// Appearances in the future of the reference time count fully.
+func decay(age, halfLife time.Duration) float64 {
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
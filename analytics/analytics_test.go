@@ -0,0 +1,133 @@
+package analytics
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/store"
+)
+
+// at returns the given hour of the given day in October 2026 (UTC).
+func at(d, hour int) time.Time {
+	return time.Date(2026, time.October, d, hour, 0, 0, 0, time.UTC)
+}
+
+// projects returns a projects snapshot fetched at t with the projects in rank order.
+func projects(t time.Time, entries ...trending.Project) store.Snapshot {
+	return *store.NewProjectsSnapshot(trending.Query{Since: trending.TimeToday}, entries, t)
+}
+
+// project returns a trending.Project with name and the period stars.
+// The overall stars are far higher, like on the trending page, and must not affect the metrics.
+func project(name string, stars int) trending.Project {
+	return trending.Project{Name: name, Stars: 100_000 + stars, PeriodStars: stars}
+}
+
+func TestProjects(t *testing.T) {
+	snapshots := []store.Snapshot{
+		projects(at(1, 8), project("a/one", 100), project("b/two", 50)),
+		// A second snapshot of the same day counts with the best rank and the most stars
+		projects(at(1, 20), project("b/two", 120), project("A/One", 110)),
+		projects(at(3, 8), project("a/one", 40)),
+		*store.NewDevelopersSnapshot(trending.Query{}, []trending.Developer{{ID: 1}}, at(3, 8)),
+	}
+
+	got := Projects(snapshots, Options{})
+	if len(got) != 2 {
+		t.Fatalf("Projects returned %d stats, want 2", len(got))
+	}
+
+	one := got[0]
+	if one.Project.Name != "a/one" {
+		t.Fatalf("Projects returned %q first, want %q", one.Project.Name, "a/one")
+	}
+	if one.Appearances != 3 || one.DaysOnTrending != 2 || one.BestRank != 1 || one.TotalStars != 150 {
+		t.Errorf("Projects returned %+v, want 3 appearances, 2 days, best rank 1 and 150 stars", one)
+	}
+	if !one.FirstSeen.Equal(at(1, 8)) || !one.LastSeen.Equal(at(3, 8)) {
+		t.Errorf("Projects returned first seen %v and last seen %v, want %v and %v", one.FirstSeen, one.LastSeen, at(1, 8), at(3, 8))
+	}
+	// 150 stars over 3 days (October 1st to 3rd)
+	if one.Velocity != 50 {
+		t.Errorf("Projects returned velocity %v, want 50", one.Velocity)
+	}
+	// Day 1: 110 stars at rank 1, two days old, day 3: 40 stars at rank 1
+	wantMomentum := 110*math.Pow(0.5, 2.0/7) + 40
+	if math.Abs(one.Momentum-wantMomentum) > 1e-9 {
+		t.Errorf("Projects returned momentum %v, want %v", one.Momentum, wantMomentum)
+	}
+
+	two := got[1]
+	if two.Project.Name != "b/two" || two.DaysOnTrending != 1 || two.BestRank != 1 || two.TotalStars != 120 || two.Velocity != 120 {
+		t.Errorf("Projects returned %+v, want b/two with 1 day, best rank 1, 120 stars and velocity 120", two)
+	}
+}
+
+func TestProjects_Options(t *testing.T) {
+	snapshots := []store.Snapshot{
+		projects(at(1, 8), project("a/one", 100)),
+		projects(at(2, 8), project("x/filler", 1), project("b/two", 100)),
+	}
+
+	// With a long half-life, the better rank of a/one outweighs the age
+	got := Projects(snapshots, Options{Now: at(2, 23), HalfLife: 1000 * 24 * time.Hour})
+	if got[0].Project.Name != "a/one" {
+		t.Errorf("Projects with a long half-life returned %q first, want %q", got[0].Project.Name, "a/one")
+	}
+
+	// With a short half-life, the recent appearance of b/two wins
+	got = Projects(snapshots, Options{Now: at(2, 23), HalfLife: time.Hour})
+	if got[0].Project.Name != "b/two" {
+		t.Errorf("Projects with a short half-life returned %q first, want %q", got[0].Project.Name, "b/two")
+	}
+}
+
+func TestProjects_Empty(t *testing.T) {
+	if got := Projects(nil, Options{}); len(got) != 0 {
+		t.Errorf("Projects returned %+v for no snapshots, want nothing", got)
+	}
+}
+
+func TestDevelopers(t *testing.T) {
+	developers := func(t time.Time, language string, entries ...trending.Developer) store.Snapshot {
+		return *store.NewDevelopersSnapshot(trending.Query{Since: trending.TimeToday, Language: language}, entries, t)
+	}
+	alice := trending.Developer{ID: 1, DisplayName: "alice"}
+	aliceRenamed := trending.Developer{ID: 1, DisplayName: "alice2"}
+	bob := trending.Developer{DisplayName: "bob"}
+
+	snapshots := []store.Snapshot{
+		developers(at(1, 8), "go", alice, bob),
+		developers(at(1, 9), "", bob, alice),
+		developers(at(2, 8), "rust", aliceRenamed),
+		projects(at(2, 8), project("a/one", 1)),
+	}
+
+	got := Developers(snapshots)
+	want := []DeveloperStats{
+		{
+			Developer:      aliceRenamed,
+			FirstSeen:      at(1, 8),
+			LastSeen:       at(2, 8),
+			Appearances:    3,
+			DaysOnTrending: 2,
+			BestRank:       1,
+			Languages:      []string{"go", "rust"},
+		},
+		{
+			Developer:      bob,
+			FirstSeen:      at(1, 8),
+			LastSeen:       at(1, 9),
+			Appearances:    2,
+			DaysOnTrending: 1,
+			BestRank:       1,
+			Languages:      []string{"go"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Developers returned %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,312 @@
+// Package blocklist removes spammy or irrelevant repositories from trending results.
+//
+// Rules are usually loaded from a YAML (or JSON) file:
+//
+//	owners: [spammer]
+//	names: ["*/awesome-*"]
+//	keywords: [airdrop, giveaway]
+//	no_language: true
+//	heuristics: true
+//
+// and applied to the projects after parsing:
+//
+//	rules, err := blocklist.Load("blocklist.yaml")
+//	if err != nil {
+//		...
+//	}
+//	report := rules.Apply(projects)
+//	for _, e := range report.Excluded {
+//		fmt.Printf("excluded %s: %s\n", e.Project.Name, e.Reasons)
+//	}
+//	projects = report.Kept
+//
+// Besides the explicit rules, an optional heuristic scorer (see Score) excludes projects that look like spam.
+// Owners, name globs and keywords ignore case, so the owner "Spammer" excludes the projects of "spammer" as well.
+package blocklist
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/rulefile"
+)
+
+// DefaultScoreThreshold is the default of Rules.ScoreThreshold.
+const DefaultScoreThreshold = 0.6
+
+// Rule is the kind of rule that excluded a project.
+type Rule string
+
+// Rules that exclude a project.
+const (
+	// RuleOwner excludes projects of an owner of Rules.Owners.
+	RuleOwner Rule = "owner"
+	// RuleName excludes projects whose full name matches a glob of Rules.Names.
+	RuleName Rule = "name"
+	// RuleKeyword excludes projects whose description contains a keyword of Rules.Keywords.
+	RuleKeyword Rule = "keyword"
+	// RuleNoLanguage excludes projects without a language, see Rules.NoLanguage.
+	RuleNoLanguage Rule = "no_language"
+	// RuleScore excludes projects with a heuristic spam score of at least Rules.ScoreThreshold.
+	RuleScore Rule = "score"
+)
+
+// Rules are the exclusion rules of a blocklist.
+type Rules struct {
+	// Owners are users or organizations whose projects are excluded, like "spammer".
+	Owners []string `yaml:"owners" json:"owners"`
+
+	// Names are globs of full project names like "*/awesome-*", see path.Match for the syntax.
+	Names []string `yaml:"names" json:"names"`
+
+	// Keywords exclude projects whose description contains one of them.
+	Keywords []string `yaml:"keywords" json:"keywords"`
+
+	// NoLanguage excludes projects without a (detected) programming language, which are often lists or documents.
+	NoLanguage bool `yaml:"no_language" json:"no_language"`
+
+	// Heuristics enables the heuristic scorer, see Score.
+	Heuristics bool `yaml:"heuristics" json:"heuristics"`
+
+	// ScoreThreshold is the score from which the heuristic scorer excludes a project.
+	// Defaults to DefaultScoreThreshold.
+	ScoreThreshold float64 `yaml:"score_threshold" json:"score_threshold"`
+}
+
+// Reason is a single reason why a project was excluded.
+type Reason struct {
+	// Rule is the kind of rule.
+	Rule Rule `json:"rule"`
+
+	// Pattern is the entry of the rules that matched, like the owner or the keyword.
+	// For RuleScore, it is the list of heuristic signals.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// String returns the reason like `keyword "airdrop"`.
+func (r Reason) String() string {
+	switch r.Rule {
+	case RuleNoLanguage:
+		return "no language"
+	case RuleScore:
+		return "spam score (" + r.Pattern + ")"
+	}
+	return fmt.Sprintf("%s %q", r.Rule, r.Pattern)
+}
+
+// Excluded is a project that was removed by the rules.
+type Excluded struct {
+	// Project is the excluded project.
+	Project trending.Project `json:"project"`
+
+	// Rank is the 1-based position of the project in the unfiltered result.
+	Rank int `json:"rank"`
+
+	// Reasons are all rules that excluded the project.
+	Reasons []Reason `json:"reasons"`
+
+	// Score is the heuristic spam score. It is only computed if Rules.Heuristics is enabled.
+	Score float64 `json:"score,omitempty"`
+}
+
+// Report is the result of applying the rules to projects.
+type Report struct {
+	// Kept are the projects that passed all rules, in their original order.
+	Kept []trending.Project `json:"kept"`
+
+	// KeptRanks are the 1-based positions of Kept in the unfiltered result:
+	// Kept[i] has the rank KeptRanks[i], like Excluded.Rank.
+	KeptRanks []int `json:"kept_ranks"`
+
+	// Excluded are the removed projects with the reasons, in their original order.
+	Excluded []Excluded `json:"excluded"`
+}
+
+// Load reads the rules from the YAML or JSON file name.
+func Load(name string) (*Rules, error) {
+	var r Rules
+	if err := rulefile.Load(name, "blocklist", &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Parse decodes the rules from YAML or JSON and validates them.
+// An empty input excludes nothing, a misspelled key like "keyword" is an error.
+func Parse(b []byte) (*Rules, error) {
+	var r Rules
+	if err := rulefile.Parse(b, "blocklist", &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Validate checks that all name globs and the score threshold are valid.
+func (r *Rules) Validate() error {
+	for _, n := range r.Names {
+		if _, err := path.Match(n, ""); err != nil {
+			return fmt.Errorf("blocklist: invalid name glob %q: %w", n, err)
+		}
+	}
+	if r.ScoreThreshold < 0 || r.ScoreThreshold > 1 {
+		return fmt.Errorf("blocklist: score threshold %v out of range, expected 0 to 1", r.ScoreThreshold)
+	}
+	return nil
+}
+
+// Apply applies the rules to projects.
+func (r *Rules) Apply(projects []trending.Project) Report {
+	var report Report
+	for i, p := range projects {
+		reasons, score := r.Check(p)
+		if len(reasons) == 0 {
+			report.Kept = append(report.Kept, p)
+			report.KeptRanks = append(report.KeptRanks, i+1)
+			continue
+		}
+		report.Excluded = append(report.Excluded, Excluded{
+			Project: p,
+			Rank:    i + 1,
+			Reasons: reasons,
+			Score:   score,
+		})
+	}
+	return report
+}
+
+// Check returns the reasons that exclude p, none if p passes all rules.
+// The score is the heuristic spam score, 0 if Heuristics is disabled.
+func (r *Rules) Check(p trending.Project) ([]Reason, float64) {
+	var reasons []Reason
+	for _, o := range r.Owners {
+		if strings.EqualFold(strings.TrimSpace(o), p.Owner) {
+			reasons = append(reasons, Reason{Rule: RuleOwner, Pattern: o})
+		}
+	}
+	name := strings.ToLower(p.Name)
+	for _, n := range r.Names {
+		// Malformed globs never get here, Parse reports them
+		if ok, _ := path.Match(strings.ToLower(strings.TrimSpace(n)), name); ok {
+			reasons = append(reasons, Reason{Rule: RuleName, Pattern: n})
+		}
+	}
+	description := strings.ToLower(p.Description)
+	for _, k := range r.Keywords {
+		if k = strings.TrimSpace(k); len(k) > 0 && strings.Contains(description, strings.ToLower(k)) {
+			reasons = append(reasons, Reason{Rule: RuleKeyword, Pattern: k})
+		}
+	}
+	if r.NoLanguage && len(p.Language) == 0 {
+		reasons = append(reasons, Reason{Rule: RuleNoLanguage})
+	}
+
+	if !r.Heuristics {
+		return reasons, 0
+	}
+	score, signals := Score(p)
+	threshold := r.ScoreThreshold
+	if threshold == 0 {
+		threshold = DefaultScoreThreshold
+	}
+	if score >= threshold {
+		reasons = append(reasons, Reason{Rule: RuleScore, Pattern: strings.Join(signals, ", ")})
+	}
+	return reasons, score
+}
+
+// spamWords are words in names or descriptions that are typical for spam repositories.
+var spamWords = []string{
+	"airdrop", "giveaway", "free money", "crypto drainer", "wallet drainer",
+	"robux", "v-bucks", "vbucks", "aimbot", "mod menu", "cheat", "keygen", "crack",
+}
+
+// Heuristic signals and their weight in the spam score.
+const (
+	spamWordWeight      = 0.5
+	awesomeListWeight   = 0.3
+	noLanguageWeight    = 0.2
+	noDescriptionWeight = 0.2
+	shoutingWeight      = 0.2
+	noContributorWeight = 0.1
+)
+
+// Score returns a heuristic spam score between 0 (looks fine) and 1 (looks like spam) for p
+// and the signals that contributed to it, like `spam word "airdrop"`.
+//
+// The signals are spam words in the name or description (0.5), "awesome" lists (0.3),
+// no language (0.2), no description (0.2), a mostly upper case description (0.2)
+// and no more than one listed contributor (0.1).
+func Score(p trending.Project) (float64, []string) {
+	var score float64
+	var signals []string
+	add := func(weight float64, signal string) {
+		score += weight
+		signals = append(signals, signal)
+	}
+
+	text := strings.ToLower(p.Name + " " + p.Description)
+	for _, w := range spamWords {
+		if containsWord(text, w) {
+			add(spamWordWeight, fmt.Sprintf("spam word %q", w))
+			break
+		}
+	}
+	if strings.HasPrefix(strings.ToLower(p.RepositoryName), "awesome") {
+		add(awesomeListWeight, "awesome list")
+	}
+	if len(p.Language) == 0 {
+		add(noLanguageWeight, "no language")
+	}
+	if len(strings.TrimSpace(p.Description)) == 0 {
+		add(noDescriptionWeight, "no description")
+	} else if isShouting(p.Description) {
+		add(shoutingWeight, "upper case description")
+	}
+	if len(p.Contributor) <= 1 {
+		add(noContributorWeight, "single contributor")
+	}
+	// Round away floating point noise like 0.6000000000000001
+	return min(math.Round(score*100)/100, 1), signals
+}
+
+// containsWord reports whether s contains word as a whole word, like "cheat" in "game-cheat",
+// but not in "awesome-cheatsheets". Any character but a letter or a digit delimits words.
+func containsWord(s, word string) bool {
+	for i := 0; ; {
+		j := strings.Index(s[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		before, _ := utf8.DecodeLastRuneInString(s[:start])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// isWordRune reports whether r is part of a word. utf8.RuneError at the start or end of a text isn't.
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// isShouting reports whether most letters of s are upper case. Short texts never shout.
+func isShouting(s string) bool {
+	var letters, upper int
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= 10 && upper*2 > letters
+}
@@ -0,0 +1,204 @@
+package blocklist
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// contributors returns n developers for trending.Project.Contributor.
+func contributors(n int) []trending.Developer {
+	return make([]trending.Developer, n)
+}
+
+func TestParse(t *testing.T) {
+	r, err := Parse([]byte(`
+owners: [spammer]
+names: ["*/awesome-*"]
+keywords: [airdrop]
+no_language: true
+heuristics: true
+score_threshold: 0.8
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	want := &Rules{
+		Owners:         []string{"spammer"},
+		Names:          []string{"*/awesome-*"},
+		Keywords:       []string{"airdrop"},
+		NoLanguage:     true,
+		Heuristics:     true,
+		ScoreThreshold: 0.8,
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("Parse returned %+v, want %+v", r, want)
+	}
+
+	if r, err := Parse(nil); err != nil || !reflect.DeepEqual(r, &Rules{}) {
+		t.Errorf("Parse of an empty file returned %+v, %v, want no rules", r, err)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "owner: [spammer]", want: "field owner not found"},
+		{input: "names: ['[a-']", want: `invalid name glob "[a-"`},
+		{input: "score_threshold: 2", want: "score threshold 2 out of range"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.input))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) returned error %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "blocklist.yaml")
+	if err := os.WriteFile(name, []byte("owners: [spammer]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := Load(name)
+	if err != nil || !reflect.DeepEqual(r.Owners, []string{"spammer"}) {
+		t.Errorf("Load returned %+v, %v, want owner spammer", r, err)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Load of a missing file returned no error")
+	}
+}
+
+func TestRules_Apply(t *testing.T) {
+	r := &Rules{
+		Owners:     []string{"Spammer"},
+		Names:      []string{"*/awesome-*"},
+		Keywords:   []string{"Airdrop"},
+		NoLanguage: true,
+	}
+	projects := []trending.Project{
+		{Name: "golang/go", Owner: "golang", RepositoryName: "go", Language: "Go"},
+		{Name: "spammer/free-airdrop", Owner: "spammer", RepositoryName: "free-airdrop", Language: "Go", Description: "Claim your airdrop"},
+		{Name: "someone/awesome-go", Owner: "someone", RepositoryName: "awesome-go"},
+		{Name: "rust-lang/rust", Owner: "rust-lang", RepositoryName: "rust", Language: "Rust"},
+	}
+
+	report := r.Apply(projects)
+
+	var kept []string
+	for _, p := range report.Kept {
+		kept = append(kept, p.Name)
+	}
+	if want := []string{"golang/go", "rust-lang/rust"}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("Apply kept %v, want %v", kept, want)
+	}
+	if want := []int{1, 4}; !reflect.DeepEqual(report.KeptRanks, want) {
+		t.Errorf("Apply kept the ranks %v, want %v", report.KeptRanks, want)
+	}
+
+	type result struct {
+		name    string
+		rank    int
+		reasons string
+	}
+	var excluded []result
+	for _, e := range report.Excluded {
+		var reasons []string
+		for _, r := range e.Reasons {
+			reasons = append(reasons, r.String())
+		}
+		excluded = append(excluded, result{e.Project.Name, e.Rank, strings.Join(reasons, "; ")})
+	}
+	want := []result{
+		{"spammer/free-airdrop", 2, `owner "Spammer"; keyword "Airdrop"`},
+		{"someone/awesome-go", 3, `name "*/awesome-*"; no language`},
+	}
+	if !reflect.DeepEqual(excluded, want) {
+		t.Errorf("Apply excluded %v, want %v", excluded, want)
+	}
+}
+
+func TestRules_ApplyHeuristics(t *testing.T) {
+	projects := []trending.Project{
+		{Name: "golang/go", RepositoryName: "go", Language: "Go", Description: "The Go programming language", Contributor: contributors(5)},
+		{Name: "x/claim", RepositoryName: "claim", Description: "FREE CRYPTO AIRDROP CLAIM NOW"},
+		{Name: "someone/awesome-go", RepositoryName: "awesome-go", Language: "Go", Description: "A curated list", Contributor: contributors(5)},
+	}
+
+	report := (&Rules{Heuristics: true}).Apply(projects)
+	if len(report.Kept) != 2 || len(report.Excluded) != 1 {
+		t.Fatalf("Apply returned %d kept and %d excluded projects, want 2 and 1", len(report.Kept), len(report.Excluded))
+	}
+	e := report.Excluded[0]
+	want := `spam score (spam word "airdrop", no language, upper case description, single contributor)`
+	if e.Project.Name != "x/claim" || e.Score != 1 || len(e.Reasons) != 1 || e.Reasons[0].String() != want {
+		t.Errorf("Apply excluded %+v, want x/claim with %s", e, want)
+	}
+
+	// A lower threshold catches the awesome list as well
+	report = (&Rules{Heuristics: true, ScoreThreshold: 0.3}).Apply(projects)
+	if len(report.Excluded) != 2 || report.Excluded[1].Score != 0.3 {
+		t.Errorf("Apply with threshold 0.3 excluded %+v, want x/claim and the awesome list", report.Excluded)
+	}
+}
+
+func TestRules_ApplyHeuristicsKeepsCheatsheets(t *testing.T) {
+	// Regression test: "cheat" in "cheatsheets" isn't a spam word
+	projects := []trending.Project{
+		{Name: "LeCoupa/awesome-cheatsheets", Owner: "LeCoupa", RepositoryName: "awesome-cheatsheets", Description: "Awesome cheatsheets for popular programming languages, frameworks and development tools.", Contributor: contributors(5)},
+	}
+
+	report := (&Rules{Heuristics: true}).Apply(projects)
+	if len(report.Kept) != 1 {
+		t.Errorf("Apply excluded %+v, want awesome-cheatsheets kept at the default threshold", report.Excluded)
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		project trending.Project
+		score   float64
+		signals []string
+	}{
+		{
+			project: trending.Project{Name: "golang/go", RepositoryName: "go", Language: "Go", Description: "The Go programming language", Contributor: contributors(5)},
+			score:   0,
+		},
+		{
+			project: trending.Project{Name: "a/awesome-list", RepositoryName: "awesome-list", Description: "A list", Contributor: contributors(1)},
+			score:   0.6,
+			signals: []string{"awesome list", "no language", "single contributor"},
+		},
+		{
+			project: trending.Project{Name: "a/robux-generator", RepositoryName: "robux-generator", Language: "Python", Contributor: contributors(2)},
+			score:   0.7,
+			signals: []string{`spam word "robux"`, "no description"},
+		},
+		{
+			project: trending.Project{Name: "a/game-cheat", RepositoryName: "game-cheat", Language: "C++", Description: "Undetected cheat", Contributor: contributors(2)},
+			score:   0.5,
+			signals: []string{`spam word "cheat"`},
+		},
+		// Spam words only match whole words
+		{
+			project: trending.Project{Name: "a/crackmapexec", RepositoryName: "crackmapexec", Language: "Python", Description: "A swiss army knife for pentesting networks", Contributor: contributors(5)},
+			score:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		score, signals := Score(tt.project)
+		if score != tt.score || !reflect.DeepEqual(signals, tt.signals) {
+			t.Errorf("Score(%s) returned %v %v, want %v %v", tt.project.Name, score, signals, tt.score, tt.signals)
+		}
+	}
+}
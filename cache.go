@@ -0,0 +1,80 @@
+package trending
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache stores the pages fetched from GitHub.
+// If Trending.Cache is set, every page is looked up in the cache before it is requested,
+// so repeated calls within a short period of time don't hit GitHub again.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the entry stored for key and whether a valid entry was found.
+	Get(key string) (CacheEntry, bool)
+
+	// Set stores entry for key.
+	Set(key string, entry CacheEntry)
+}
+
+// CacheEntry is a single page stored in a Cache.
+type CacheEntry struct {
+	// Body is the HTML of the page.
+	Body []byte
+
+	// FetchedAt is the time the page was fetched from GitHub.
+	FetchedAt time.Time
+}
+
+// MemoryCache is an in-memory Cache whose entries expire after a fixed time to live.
+// Use NewMemoryCache to create one.
+type MemoryCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+}
+
+// NewMemoryCache returns an empty MemoryCache whose entries are valid for ttl.
+//
+// GitHub updates the trending pages only a few times per hour, a ttl of a few minutes is a good start:
+//
+//	trend := trending.NewTrending()
+//	trend.Cache = trending.NewMemoryCache(10 * time.Minute)
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		ttl:     ttl,
+		entries: make(map[string]CacheEntry),
+	}
+}
+
+// Get returns the entry stored for key, if it exists and is not expired yet.
+func (c *MemoryCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || c.expired(entry, time.Now()) {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Set stores entry for key and removes all expired entries.
+func (c *MemoryCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range c.entries {
+		if c.expired(e, now) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = entry
+}
+
+// expired reports whether entry is older than the time to live of c at now.
+func (c *MemoryCache) expired(entry CacheEntry, now time.Time) bool {
+	return now.Sub(entry.FetchedAt) >= c.ttl
+}
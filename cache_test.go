@@ -0,0 +1,63 @@
+package trending
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache(time.Minute)
+
+	if _, ok := c.Get("https://github.com/trending"); ok {
+		t.Error("MemoryCache.Get returned an entry for an empty cache")
+	}
+
+	fresh := CacheEntry{Body: []byte("fresh"), FetchedAt: time.Now()}
+	c.Set("https://github.com/trending", fresh)
+	if got, ok := c.Get("https://github.com/trending"); !ok || string(got.Body) != "fresh" {
+		t.Errorf("MemoryCache.Get returned %q, %v, want %q, true", got.Body, ok, "fresh")
+	}
+
+	expired := CacheEntry{Body: []byte("expired"), FetchedAt: time.Now().Add(-2 * time.Minute)}
+	c.Set("https://github.com/trending/developers", expired)
+	if _, ok := c.Get("https://github.com/trending/developers"); ok {
+		t.Error("MemoryCache.Get returned an expired entry")
+	}
+}
+
+func TestTrending_Cache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+	client.Cache = NewMemoryCache(time.Minute)
+
+	first, err := client.FetchProjects(Query{Since: TimeToday})
+	if err != nil {
+		t.Fatalf("FetchProjects returned error: %v", err)
+	}
+	second, err := client.FetchProjects(Query{Since: TimeToday})
+	if err != nil {
+		t.Fatalf("FetchProjects returned error: %v", err)
+	}
+	if _, err := client.GetLanguages(); err != nil {
+		t.Fatalf("GetLanguages returned error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("Trending sent %d requests, want 2 (one for the projects, one for the languages)", requests)
+	}
+	if !second.FetchedAt.Equal(first.FetchedAt) {
+		t.Errorf("Cached result was fetched at %v, want %v", second.FetchedAt, first.FetchedAt)
+	}
+	if len(second.Projects) != len(first.Projects) {
+		t.Errorf("Cached result has %d projects, want %d", len(second.Projects), len(first.Projects))
+	}
+}
@@ -0,0 +1,230 @@
+// Package chat turns trending projects and developers into chat messages.
+//
+// Supported platforms are Slack (Block Kit), Discord (embeds), Microsoft Teams (Adaptive Cards)
+// and Matrix (HTML messages). Every message shows the language color, the stars of the period
+// and avatars where the platform allows it:
+//
+//	f, err := chat.New(chat.Slack, chat.Options{
+//		Query:    trending.Query{Since: trending.TimeToday, Language: "go"},
+//		MaxItems: 5,
+//	})
+//	if err != nil {
+//		...
+//	}
+//	msg, err := f.Projects(projects)
+//	if err != nil {
+//		...
+//	}
+//	err = chat.Post(ctx, http.DefaultClient, "https://hooks.slack.com/services/...", msg)
+//
+// Messages are JSON documents ready to be POSTed to an incoming webhook of the platform.
+// For Matrix, the message is the content of an m.room.message event. Matrix has no incoming
+// webhooks itself, so it has to be sent through a bridge or the client-server API.
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/format"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// Supported platforms.
+const (
+	// Slack are Block Kit messages for Slack incoming webhooks.
+	Slack = "slack"
+	// Discord are embed messages for Discord webhooks.
+	Discord = "discord"
+	// Teams are Adaptive Card messages for Microsoft Teams incoming webhooks.
+	Teams = "teams"
+	// Matrix are HTML messages (m.room.message content) for Matrix rooms.
+	Matrix = "matrix"
+)
+
+// DefaultMaxItems is the default number of projects or developers in a message.
+const DefaultMaxItems = 10
+
+// object is a JSON object of a message.
+type object = map[string]any
+
+// defaultColor is used for projects without a known language color.
+const defaultColor = "#8b949e"
+
+// Formatter creates chat messages for a single platform.
+type Formatter interface {
+	// Projects returns a message listing projects.
+	Projects(projects []trending.Project) ([]byte, error)
+
+	// Developers returns a message listing developers.
+	Developers(developers []trending.Developer) ([]byte, error)
+}
+
+// Options configure a Formatter.
+type Options struct {
+	// Query is the query of the result. It is used for the title and the period of the stars.
+	Query trending.Query
+
+	// Title is the headline of the message.
+	// Defaults to a title derived from Query like "Trending repositories on GitHub today (go)".
+	Title string
+
+	// MaxItems is the maximum number of projects or developers in a message.
+	// Defaults to DefaultMaxItems. Discord limits it to 10 embeds per message.
+	MaxItems int
+}
+
+// formatters maps the platforms to their constructor.
+var formatters = map[string]func(opts Options) Formatter{
+	Slack:   func(opts Options) Formatter { return slack{opts} },
+	Discord: func(opts Options) Formatter { return discord{opts} },
+	Teams:   func(opts Options) Formatter { return teams{opts} },
+	Matrix:  func(opts Options) Formatter { return matrix{opts} },
+}
+
+// Platforms returns the names of all supported platforms in alphabetical order.
+func Platforms() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns the Formatter for platform like "slack" or "discord".
+func New(platform string, opts Options) (Formatter, error) {
+	newFormatter, ok := formatters[platform]
+	if !ok {
+		return nil, fmt.Errorf("chat: unknown platform %q, expected one of %s", platform, strings.Join(Platforms(), ", "))
+	}
+	if opts.MaxItems <= 0 {
+		opts.MaxItems = DefaultMaxItems
+	}
+	return newFormatter(opts), nil
+}
+
+// Post sends msg to the incoming webhook webhookURL.
+// A response status other than 2xx is reported as error.
+func Post(ctx context.Context, client *http.Client, webhookURL string, msg []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(msg))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("chat: posting message failed with status %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// projectsTitle returns the title of a projects message.
+func (o Options) projectsTitle() string {
+	if len(o.Title) > 0 {
+		return o.Title
+	}
+	return "Trending repositories on GitHub " + shared.Period(o.Query.Since) + o.languageSuffix()
+}
+
+// developersTitle returns the title of a developers message.
+func (o Options) developersTitle() string {
+	if len(o.Title) > 0 {
+		return o.Title
+	}
+	return "Trending developers on GitHub " + shared.Period(o.Query.Since) + o.languageSuffix()
+}
+
+// languageSuffix returns the language of the query as " (go)" or an empty string.
+func (o Options) languageSuffix() string {
+	if len(o.Query.Language) == 0 {
+		return ""
+	}
+	return " (" + o.Query.Language + ")"
+}
+
+// stars returns the stars of p in the period like "1,234 stars today".
+func (o Options) stars(p trending.Project) string {
+	return thousands(p.PeriodStars) + " stars " + shared.Period(o.Query.Since)
+}
+
+// limitProjects returns at most max projects.
+func limitProjects(projects []trending.Project, max int) []trending.Project {
+	if len(projects) > max {
+		return projects[:max]
+	}
+	return projects
+}
+
+// limitDevelopers returns at most max developers.
+func limitDevelopers(developers []trending.Developer, max int) []trending.Developer {
+	if len(developers) > max {
+		return developers[:max]
+	}
+	return developers
+}
+
+// languageColor returns the hex color of the language of p or a neutral gray.
+func languageColor(p trending.Project) string {
+	if len(p.LanguageColor) > 0 {
+		return p.LanguageColor
+	}
+	if c := format.LanguageColor(p.Language); len(c) > 0 {
+		return c
+	}
+	return defaultColor
+}
+
+// ownerAvatar returns the avatar of the first contributor of p, which is usually the owner, or an empty string.
+func ownerAvatar(p trending.Project) string {
+	for _, d := range p.Contributor {
+		if d.Avatar != nil {
+			return d.Avatar.String()
+		}
+	}
+	return ""
+}
+
+// marshal encodes msg as JSON.
+// Other than json.Marshal it keeps "<", ">" and "&" as they are, which are common in messages.
+func marshal(msg any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(msg); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// itoa is a shorthand for strconv.Itoa
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+// thousands formats n with "," as thousand separator like "12,345".
+func thousands(n int) string {
+	s := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + thousands(-n)
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
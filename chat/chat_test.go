@@ -0,0 +1,130 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/testutil"
+)
+
+// testGolden compares the indented JSON msg with the golden file testdata/name.golden.
+func testGolden(t *testing.T, name string, msg []byte) {
+	t.Helper()
+
+	var got bytes.Buffer
+	if err := json.Indent(&got, msg, "", "  "); err != nil {
+		t.Fatalf("Message is no valid JSON: %v", err)
+	}
+	got.WriteByte('\n')
+	testutil.Golden(t, name, got.Bytes())
+}
+
+// The fixtures are the daily trending pages, so the messages show the stars of today.
+func TestFormatter_Golden(t *testing.T) {
+	client := testutil.FixtureClient()
+	projects, err := client.GetProjects(trending.TimeToday, "")
+	if err != nil {
+		t.Fatalf("GetProjects returned error: %v", err)
+	}
+	developers, err := client.GetDevelopers(trending.TimeToday, "")
+	if err != nil {
+		t.Fatalf("GetDevelopers returned error: %v", err)
+	}
+
+	opts := Options{
+		Query:    trending.Query{Since: trending.TimeToday},
+		MaxItems: 3,
+	}
+	for _, platform := range Platforms() {
+		f, err := New(platform, opts)
+		if err != nil {
+			t.Fatalf("New returned error: %v", err)
+		}
+
+		msg, err := f.Projects(projects)
+		if err != nil {
+			t.Fatalf("%s: Projects returned error: %v", platform, err)
+		}
+		testGolden(t, platform+"_projects", msg)
+
+		msg, err = f.Developers(developers)
+		if err != nil {
+			t.Fatalf("%s: Developers returned error: %v", platform, err)
+		}
+		testGolden(t, platform+"_developers", msg)
+	}
+}
+
+func TestDiscord_MaxEmbeds(t *testing.T) {
+	projects := make([]trending.Project, 25)
+	for i := range projects {
+		projects[i] = trending.Project{Name: "owner/repository"}
+	}
+
+	f, err := New(Discord, Options{MaxItems: 25})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	msg, err := f.Projects(projects)
+	if err != nil {
+		t.Fatalf("Projects returned error: %v", err)
+	}
+
+	var got struct {
+		Embeds []any
+	}
+	if err := json.Unmarshal(msg, &got); err != nil {
+		t.Fatalf("Decoding message failed: %v", err)
+	}
+	if len(got.Embeds) != 10 {
+		t.Errorf("Discord message has %d embeds, want 10", len(got.Embeds))
+	}
+}
+
+func TestNew_UnknownPlatform(t *testing.T) {
+	if _, err := New("irc", Options{}); err == nil {
+		t.Error("New returned no error for unknown platform \"irc\"")
+	}
+}
+
+func TestPost(t *testing.T) {
+	var gotBody []byte
+	var gotContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotContentType = r.Header.Get("Content-Type")
+		if r.URL.Path == "/invalid" {
+			http.Error(w, "invalid_blocks", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	msg := []byte(`{"text":"hello"}`)
+	if err := Post(context.Background(), server.Client(), server.URL, msg); err != nil {
+		t.Fatalf("Post returned error: %v", err)
+	}
+	if !bytes.Equal(gotBody, msg) || gotContentType != "application/json" {
+		t.Errorf("Webhook received %s (%s), want %s (application/json)", gotBody, gotContentType, msg)
+	}
+
+	err := Post(context.Background(), server.Client(), server.URL+"/invalid", msg)
+	if err == nil || !strings.Contains(err.Error(), "invalid_blocks") {
+		t.Errorf("Post returned %v, want an error containing the response body", err)
+	}
+}
+
+func TestThousands(t *testing.T) {
+	tests := map[int]string{0: "0", 999: "999", 1000: "1,000", 240084: "240,084", 1234567: "1,234,567", -1500: "-1,500"}
+	for n, want := range tests {
+		if got := thousands(n); got != want {
+			t.Errorf("thousands(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
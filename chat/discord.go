@@ -0,0 +1,84 @@
+package chat
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// discordMaxEmbeds is the maximum number of embeds Discord accepts in a single message.
+const discordMaxEmbeds = 10
+
+// discord creates embed messages for Discord webhooks.
+type discord struct {
+	opts Options
+}
+
+// Projects returns a message listing projects.
+func (d discord) Projects(projects []trending.Project) ([]byte, error) {
+	var embeds []object
+	for i, p := range limitProjects(projects, d.maxItems()) {
+		embed := object{
+			"title": itoa(i+1) + ". " + p.Name,
+			"url":   shared.URLString(p.URL),
+			"color": discordColor(languageColor(p)),
+			"fields": []object{
+				{"name": "Stars", "value": "⭐ " + d.opts.stars(p), "inline": true},
+			},
+		}
+		if len(p.Description) > 0 {
+			embed["description"] = shared.Truncate(p.Description, 300)
+		}
+		if len(p.Language) > 0 {
+			embed["fields"] = append(embed["fields"].([]object), object{"name": "Language", "value": p.Language, "inline": true})
+		}
+		if avatar := ownerAvatar(p); len(avatar) > 0 {
+			embed["thumbnail"] = object{"url": avatar}
+		}
+		embeds = append(embeds, embed)
+	}
+
+	return marshal(object{
+		"content": "**" + d.opts.projectsTitle() + "**",
+		"embeds":  embeds,
+	})
+}
+
+// Developers returns a message listing developers.
+func (d discord) Developers(developers []trending.Developer) ([]byte, error) {
+	var embeds []object
+	for i, dev := range limitDevelopers(developers, d.maxItems()) {
+		embed := object{
+			"title": itoa(i+1) + ". " + dev.DisplayName,
+			"url":   shared.URLString(dev.URL),
+		}
+		if len(dev.FullName) > 0 {
+			embed["description"] = dev.FullName
+		}
+		if dev.Avatar != nil {
+			embed["thumbnail"] = object{"url": dev.Avatar.String()}
+		}
+		embeds = append(embeds, embed)
+	}
+
+	return marshal(object{
+		"content": "**" + d.opts.developersTitle() + "**",
+		"embeds":  embeds,
+	})
+}
+
+// maxItems returns the number of items of a message, limited to the embeds Discord accepts.
+func (d discord) maxItems() int {
+	return min(d.opts.MaxItems, discordMaxEmbeds)
+}
+
+// discordColor converts a hex color like "#00ADD8" into the integer Discord expects.
+func discordColor(hex string) int {
+	c, err := strconv.ParseInt(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil {
+		return 0
+	}
+	return int(c)
+}
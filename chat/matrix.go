@@ -0,0 +1,72 @@
+package chat
+
+import (
+	"html"
+	"strings"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// matrix creates HTML messages for Matrix rooms.
+// The message is the content of an m.room.message event with a plain text fallback.
+// Avatars are not shown, because Matrix clients only render images from mxc:// URLs.
+type matrix struct {
+	opts Options
+}
+
+// Projects returns a message listing projects.
+func (m matrix) Projects(projects []trending.Project) ([]byte, error) {
+	title := m.opts.projectsTitle()
+
+	plain := []string{title}
+	var rich strings.Builder
+	rich.WriteString("<h4>" + html.EscapeString(title) + "</h4><ol>")
+	for i, p := range limitProjects(projects, m.opts.MaxItems) {
+		plain = append(plain, itoa(i+1)+". "+p.Name+" ("+m.opts.stars(p)+") "+shared.URLString(p.URL))
+
+		rich.WriteString(`<li><a href="` + html.EscapeString(shared.URLString(p.URL)) + `"><b>` + html.EscapeString(p.Name) + "</b></a>")
+		if len(p.Description) > 0 {
+			rich.WriteString("<br>" + html.EscapeString(p.Description))
+		}
+		rich.WriteString(`<br><font data-mx-color="` + languageColor(p) + `">●</font> `)
+		if len(p.Language) > 0 {
+			rich.WriteString(html.EscapeString(p.Language) + " · ")
+		}
+		rich.WriteString("★ " + html.EscapeString(m.opts.stars(p)) + "</li>")
+	}
+	rich.WriteString("</ol>")
+
+	return matrixMessage(strings.Join(plain, "\n"), rich.String())
+}
+
+// Developers returns a message listing developers.
+func (m matrix) Developers(developers []trending.Developer) ([]byte, error) {
+	title := m.opts.developersTitle()
+
+	plain := []string{title}
+	var rich strings.Builder
+	rich.WriteString("<h4>" + html.EscapeString(title) + "</h4><ol>")
+	for i, d := range limitDevelopers(developers, m.opts.MaxItems) {
+		plain = append(plain, itoa(i+1)+". "+d.DisplayName+" "+shared.URLString(d.URL))
+
+		rich.WriteString(`<li><a href="` + html.EscapeString(shared.URLString(d.URL)) + `"><b>` + html.EscapeString(d.DisplayName) + "</b></a>")
+		if len(d.FullName) > 0 {
+			rich.WriteString(" " + html.EscapeString(d.FullName))
+		}
+		rich.WriteString("</li>")
+	}
+	rich.WriteString("</ol>")
+
+	return matrixMessage(strings.Join(plain, "\n"), rich.String())
+}
+
+// matrixMessage returns the content of an m.room.message event with body as fallback for formattedBody.
+func matrixMessage(body, formattedBody string) ([]byte, error) {
+	return marshal(object{
+		"msgtype":        "m.notice",
+		"body":           body,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": formattedBody,
+	})
+}
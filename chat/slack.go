@@ -0,0 +1,89 @@
+package chat
+
+import (
+	"strings"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// slack creates Block Kit messages for Slack incoming webhooks.
+// Every item is an attachment, because only attachments can show the language color.
+type slack struct {
+	opts Options
+}
+
+// slackEscaper escapes the control characters of Slack mrkdwn.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Projects returns a message listing projects.
+func (s slack) Projects(projects []trending.Project) ([]byte, error) {
+	title := s.opts.projectsTitle()
+
+	var attachments []object
+	for i, p := range limitProjects(projects, s.opts.MaxItems) {
+		lines := []string{"*" + itoa(i+1) + ". <" + shared.URLString(p.URL) + "|" + slackEscaper.Replace(p.Name) + ">*"}
+		if len(p.Description) > 0 {
+			lines = append(lines, slackEscaper.Replace(p.Description))
+		}
+		meta := ":star: " + s.opts.stars(p)
+		if len(p.Language) > 0 {
+			meta = "`" + slackEscaper.Replace(p.Language) + "` · " + meta
+		}
+		lines = append(lines, meta)
+
+		section := object{
+			"type": "section",
+			"text": object{"type": "mrkdwn", "text": strings.Join(lines, "\n")},
+		}
+		if avatar := ownerAvatar(p); len(avatar) > 0 {
+			section["accessory"] = object{"type": "image", "image_url": avatar, "alt_text": p.Owner}
+		}
+
+		attachments = append(attachments, object{
+			"color":  languageColor(p),
+			"blocks": []object{section},
+		})
+	}
+
+	return marshal(object{
+		"text":        title,
+		"blocks":      []object{slackHeader(title)},
+		"attachments": attachments,
+	})
+}
+
+// Developers returns a message listing developers.
+func (s slack) Developers(developers []trending.Developer) ([]byte, error) {
+	title := s.opts.developersTitle()
+
+	blocks := []object{slackHeader(title)}
+	for i, d := range limitDevelopers(developers, s.opts.MaxItems) {
+		text := "*" + itoa(i+1) + ". <" + shared.URLString(d.URL) + "|" + slackEscaper.Replace(d.DisplayName) + ">*"
+		if len(d.FullName) > 0 {
+			text += "\n" + slackEscaper.Replace(d.FullName)
+		}
+
+		section := object{
+			"type": "section",
+			"text": object{"type": "mrkdwn", "text": text},
+		}
+		if d.Avatar != nil {
+			section["accessory"] = object{"type": "image", "image_url": d.Avatar.String(), "alt_text": d.DisplayName}
+		}
+		blocks = append(blocks, section)
+	}
+
+	return marshal(object{
+		"text":   title,
+		"blocks": blocks,
+	})
+}
+
+// slackHeader returns a header block with text.
+func slackHeader(text string) object {
+	return object{
+		"type": "header",
+		"text": object{"type": "plain_text", "text": text},
+	}
+}
@@ -0,0 +1,104 @@
+package chat
+
+import (
+	"encoding/base64"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// teams creates Adaptive Card messages for Microsoft Teams incoming webhooks.
+type teams struct {
+	opts Options
+}
+
+// Projects returns a message listing projects.
+func (t teams) Projects(projects []trending.Project) ([]byte, error) {
+	body := []object{teamsTitle(t.opts.projectsTitle())}
+	for i, p := range limitProjects(projects, t.opts.MaxItems) {
+		items := []object{
+			{"type": "TextBlock", "text": itoa(i+1) + ". [" + p.Name + "](" + shared.URLString(p.URL) + ")", "weight": "Bolder", "wrap": true},
+		}
+		if len(p.Description) > 0 {
+			items = append(items, object{"type": "TextBlock", "text": p.Description, "wrap": true, "isSubtle": true, "spacing": "None"})
+		}
+
+		meta := "★ " + t.opts.stars(p)
+		if len(p.Language) > 0 {
+			meta = p.Language + " · " + meta
+		}
+		items = append(items, object{
+			"type":    "ColumnSet",
+			"spacing": "None",
+			"columns": []object{
+				{"type": "Column", "width": "auto", "verticalContentAlignment": "Center", "items": []object{
+					{"type": "Image", "url": colorSwatch(languageColor(p)), "width": "12px", "altText": p.Language},
+				}},
+				{"type": "Column", "width": "stretch", "items": []object{
+					{"type": "TextBlock", "text": meta, "size": "Small", "wrap": true},
+				}},
+			},
+		})
+
+		body = append(body, teamsRow(ownerAvatar(p), p.Owner, items))
+	}
+
+	return teamsMessage(body)
+}
+
+// Developers returns a message listing developers.
+func (t teams) Developers(developers []trending.Developer) ([]byte, error) {
+	body := []object{teamsTitle(t.opts.developersTitle())}
+	for i, d := range limitDevelopers(developers, t.opts.MaxItems) {
+		items := []object{
+			{"type": "TextBlock", "text": itoa(i+1) + ". [" + d.DisplayName + "](" + shared.URLString(d.URL) + ")", "weight": "Bolder", "wrap": true},
+		}
+		if len(d.FullName) > 0 {
+			items = append(items, object{"type": "TextBlock", "text": d.FullName, "wrap": true, "isSubtle": true, "spacing": "None"})
+		}
+		body = append(body, teamsRow(shared.URLString(d.Avatar), d.DisplayName, items))
+	}
+
+	return teamsMessage(body)
+}
+
+// teamsMessage wraps the card body into a message for incoming webhooks.
+func teamsMessage(body []object) ([]byte, error) {
+	return marshal(object{
+		"type": "message",
+		"attachments": []object{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": object{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body":    body,
+			},
+		}},
+	})
+}
+
+// teamsTitle returns the headline of a card.
+func teamsTitle(text string) object {
+	return object{"type": "TextBlock", "text": text, "size": "Large", "weight": "Bolder", "wrap": true}
+}
+
+// teamsRow returns a single item of a card with the avatar on the left and items on the right.
+func teamsRow(avatar, altText string, items []object) object {
+	var columns []object
+	if len(avatar) > 0 {
+		columns = append(columns, object{"type": "Column", "width": "auto", "items": []object{
+			{"type": "Image", "url": avatar, "size": "Small", "style": "Person", "altText": altText},
+		}})
+	}
+	columns = append(columns, object{"type": "Column", "width": "stretch", "items": items})
+
+	return object{"type": "ColumnSet", "separator": true, "columns": columns}
+}
+
+// colorSwatch returns a data URL of a small SVG circle in color.
+// Adaptive Cards can not color text freely, but they can show images.
+func colorSwatch(color string) string {
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" width="12" height="12"><circle cx="6" cy="6" r="6" fill="` + color + `"/></svg>`
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(svg))
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/watch"
+	"github.com/andygrunwald/go-trending/watchlist"
+)
+
+// errMatched signals that the check command found watchlist matches. It exits with code 3.
+var errMatched = errors.New("watchlist matched")
+
+// runCheck implements the "check" command.
+//
+// It fetches the trending pages once and prints every project or developer matching the watchlist.
+// The exit code is 3 if anything matched, so that the command can be used in scripts and CI jobs.
+func runCheck(a *app, args []string) error {
+	var (
+		cf             clientFlags
+		watchlistFile  string
+		since          string
+		languages      string
+		spokenLanguage string
+		developers     bool
+		output         string
+	)
+	fs := a.newFlagSet("check")
+	cf.register(fs)
+	fs.StringVar(&watchlistFile, "watchlist", "", "YAML file with repositories, owners, keywords and developers to look out for (required)")
+	fs.StringVar(&since, "since", trending.TimeToday, "period of time: daily, weekly or monthly")
+	fs.StringVar(&languages, "language", "", "comma separated list of programing languages to check, e.g. go,rust; empty checks all languages")
+	fs.StringVar(&spokenLanguage, "spoken-language", "", "spoken language code of the projects, e.g. en")
+	fs.BoolVar(&developers, "developers", false, "check trending developers in addition to projects")
+	fs.StringVar(&output, "format", "text", "output format: text or json")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if len(watchlistFile) == 0 {
+		fmt.Fprintln(fs.Output(), "missing -watchlist")
+		fs.Usage()
+		return errUsage
+	}
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid format %q, expected text or json", output)
+	}
+	qf := queryFlags{since: since, spokenLanguage: spokenLanguage}
+	if _, err := qf.query(a.stderr); err != nil {
+		return err
+	}
+	wl, err := watchlist.Load(watchlistFile)
+	if err != nil {
+		return err
+	}
+	targets, err := watchTargets(since, languages, spokenLanguage, developers, a.stderr)
+	if err != nil {
+		return err
+	}
+	trend, err := cf.newTrending()
+	if err != nil {
+		return err
+	}
+
+	// The first poll of a watcher only emits the watchlist matches
+	var errs []error
+	w := watch.New(trend, targets, watch.Options{
+		Watchlist: wl,
+		ErrorHandler: func(t watch.Target, err error) {
+			errs = append(errs, fmt.Errorf("fetching %s %+v failed: %w", t.Kind(), t.Query, err))
+		},
+	})
+	matches := []watchlist.Match{}
+	for _, e := range w.Poll(a.ctx) {
+		if e.Match != nil {
+			matches = append(matches, *e.Match)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if output == "json" {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(matches)
+	} else {
+		err = writeMatches(a.stdout, matches)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(matches) > 0 {
+		return errMatched
+	}
+	return nil
+}
+
+// writeMatches writes matches as aligned table into w.
+func writeMatches(w io.Writer, matches []watchlist.Match) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANK\tNAME\tSTARS\tRULE\tPATTERN\tSINCE\tLANGUAGE")
+	for _, m := range matches {
+		language := m.Query.Language
+		if len(language) == 0 {
+			language = "-"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\t%s\n", m.Rank, m.Name(), m.Stars, m.Rule, m.Pattern, m.Query.Since, language)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,667 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/blocklist"
+	"github.com/andygrunwald/go-trending/enrich"
+	"github.com/andygrunwald/go-trending/filter"
+	"github.com/andygrunwald/go-trending/format"
+	"github.com/andygrunwald/go-trending/store"
+)
+
+// errUsage signals that the command line was invalid and the usage was already printed.
+var errUsage = errors.New("invalid usage")
+
+// clientFlags are the flags to configure the trending client. They are shared by all commands.
+type clientFlags struct {
+	baseURL  string
+	timeout  time.Duration
+	logLevel string
+
+	// logOutput is where the log is written to, the output of the flag set.
+	logOutput io.Writer
+}
+
+// register adds the client flags to fs.
+func (f *clientFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.baseURL, "base-url", "", "base URL of the GitHub website, e.g. https://github.example.com for GitHub Enterprise (default https://github.com)")
+	fs.DurationVar(&f.timeout, "timeout", 30*time.Second, "timeout of a single HTTP request")
+	fs.StringVar(&f.logLevel, "log-level", "", "log requests and parse warnings to stderr: debug, info, warn or error (default no logging)")
+	f.logOutput = fs.Output()
+}
+
+// newTrending creates a trending client configured by the flags.
+func (f *clientFlags) newTrending() (*trending.Trending, error) {
+	trend := trending.NewTrendingWithClient(&http.Client{
+		Timeout: f.timeout,
+	})
+
+	if len(f.baseURL) > 0 {
+		u, err := url.Parse(f.baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL %q: %w", f.baseURL, err)
+		}
+		trend.BaseURL = u
+	}
+
+	if len(f.logLevel) > 0 {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(f.logLevel)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q, expected debug, info, warn or error", f.logLevel)
+		}
+		trend.Logger = slog.New(slog.NewTextHandler(f.logOutput, &slog.HandlerOptions{Level: level}))
+	}
+
+	return trend, nil
+}
+
+// queryFlags are the flags to filter trending projects and developers.
+type queryFlags struct {
+	since          string
+	language       string
+	spokenLanguage string
+	sponsorable    bool
+}
+
+// register adds the query flags to fs.
+// Only flags that have an effect for the given mode (projects or developers) are added.
+func (f *queryFlags) register(fs *flag.FlagSet, developers bool) {
+	fs.StringVar(&f.since, "since", trending.TimeToday, "period of time: daily, weekly or monthly")
+	fs.StringVar(&f.language, "language", "", "programing language as listed by the languages command, e.g. go; names and aliases like C# or golang are accepted")
+	if developers {
+		fs.BoolVar(&f.sponsorable, "sponsorable", false, "only list developers who can be sponsored")
+	} else {
+		fs.StringVar(&f.spokenLanguage, "spoken-language", "", "spoken language code as listed by the spoken-languages command, e.g. en")
+	}
+}
+
+// query converts the flags into a trending.Query. Warnings about unknown languages are written to stderr.
+func (f *queryFlags) query(stderr io.Writer) (trending.Query, error) {
+	switch f.since {
+	case trending.TimeToday, trending.TimeWeek, trending.TimeMonth:
+	default:
+		return trending.Query{}, fmt.Errorf("invalid period %q, expected one of %s, %s or %s", f.since, trending.TimeToday, trending.TimeWeek, trending.TimeMonth)
+	}
+
+	// Accept names and aliases like "C#" or "golang" in addition to URL names
+	language, err := resolveLanguage(f.language, stderr)
+	if err != nil {
+		return trending.Query{}, err
+	}
+
+	return trending.Query{
+		Since:          f.since,
+		Language:       language,
+		SpokenLanguage: f.spokenLanguage,
+		Sponsorable:    f.sponsorable,
+	}, nil
+}
+
+// resolveLanguage resolves name like trending.ResolveLanguage.
+// The bundled catalog may lag behind GitHub, so unknown languages that look like a URL name
+// are used anyway and the error is written as a warning with suggestions to stderr.
+func resolveLanguage(name string, stderr io.Writer) (string, error) {
+	language, err := trending.ResolveLanguage(name)
+	var unknown *trending.UnknownLanguageError
+	if errors.As(err, &unknown) && len(unknown.URLName) > 0 {
+		fmt.Fprintf(stderr, "go-trending: warning: %v (using %q anyway)\n", err, unknown.URLName)
+		return unknown.URLName, nil
+	}
+	return language, err
+}
+
+// outputFlags are the flags to control the output format.
+type outputFlags struct {
+	format   string
+	columns  string
+	sort     string
+	template string
+}
+
+// register adds the output flags to fs.
+// columns is the list of available columns shown in the help.
+// If templates is true, the -template flag is added as well.
+func (f *outputFlags) register(fs *flag.FlagSet, columns []string, templates bool) {
+	fs.StringVar(&f.format, "format", format.Table, "output format: "+strings.Join(format.Names(), ", "))
+	fs.StringVar(&f.columns, "columns", "", "comma separated list of columns to print: "+strings.Join(columns, ", "))
+	fs.StringVar(&f.sort, "sort", "", `column to sort by, prefix with "-" for descending order, e.g. -stars`)
+	if templates {
+		fs.StringVar(&f.template, "template", "", "render the output with a Go text/template file or a built-in template ("+strings.Join(format.TemplateNames(), ", ")+"); overrides -format")
+	}
+}
+
+// parseTemplate returns the template configured by -template or nil if no template is set.
+// Names of built-in templates take precedence over files.
+func (f *outputFlags) parseTemplate() (*template.Template, error) {
+	if len(f.template) == 0 {
+		return nil, nil
+	}
+	for _, name := range format.TemplateNames() {
+		if name == f.template {
+			return format.BuiltinTemplate(name)
+		}
+	}
+	return format.ParseTemplateFile(f.template)
+}
+
+// formatter creates the format.Formatter configured by the flags.
+// ranks are the ranks of the written items on the trending page, see format.Options.Ranks.
+func (f *outputFlags) formatter(ranks []int) (format.Formatter, error) {
+	opts := format.Options{
+		Sort:  f.sort,
+		Ranks: ranks,
+	}
+	if len(f.columns) > 0 {
+		for _, c := range strings.Split(f.columns, ",") {
+			opts.Columns = append(opts.Columns, strings.TrimSpace(c))
+		}
+	}
+	return format.New(f.format, opts)
+}
+
+// storeFlags are the flags to persist fetched results.
+type storeFlags struct {
+	path string
+}
+
+// register adds the store flags to fs.
+func (f *storeFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.path, "store", "", "save the result as snapshot into this database file")
+}
+
+// save stores s into the database configured by -store. Without -store, nothing happens.
+func (f *storeFlags) save(s *store.Snapshot) error {
+	if len(f.path) == 0 {
+		return nil
+	}
+
+	db, err := store.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Save(s)
+}
+
+// previous returns the latest snapshot of kind with the query q from the database configured by -store.
+// Without -store or without such a snapshot, nil is returned.
+func (f *storeFlags) previous(kind string, q trending.Query) (*store.Snapshot, error) {
+	if len(f.path) == 0 {
+		return nil, nil
+	}
+
+	db, err := store.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	snapshots, err := db.Snapshots(store.Filter{
+		Kind:           kind,
+		Since:          q.Since,
+		Language:       q.Language,
+		SpokenLanguage: q.SpokenLanguage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Empty fields of the filter match everything, like snapshots of all languages
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if s := &snapshots[i]; s.Query.Language == q.Language && s.Query.SpokenLanguage == q.SpokenLanguage {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// filterFlags are the flags to select a subset of the fetched results.
+type filterFlags struct {
+	expr  string
+	lists listFlag
+}
+
+// register adds the filter flags to fs.
+// fields is the list of available fields shown in the help.
+func (f *filterFlags) register(fs *flag.FlagSet, fields []string) {
+	fs.StringVar(&f.expr, "filter", "", `only print entries matching this expression, e.g. 'stars > 500 && language in ("Go", "Rust")'; fields: `+strings.Join(fields, ", "))
+	fs.Var(&f.lists, "list", "named list for the in operator of -filter as name=file, one value per line; can be repeated")
+}
+
+// options returns the filter.Options with the lists of -list.
+func (f *filterFlags) options() (filter.Options, error) {
+	opts := filter.Options{Lists: make(map[string][]string)}
+	for _, l := range f.lists {
+		name, path, ok := strings.Cut(l, "=")
+		if !ok || len(name) == 0 || len(path) == 0 {
+			return opts, fmt.Errorf("invalid list %q, expected name=file", l)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return opts, err
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if len(line) > 0 && !strings.HasPrefix(line, "#") {
+				opts.Lists[name] = append(opts.Lists[name], line)
+			}
+		}
+	}
+	return opts, nil
+}
+
+// projects returns the parsed -filter for projects.
+func (f *filterFlags) projects() (*filter.Filter[trending.Project], error) {
+	opts, err := f.options()
+	if err != nil {
+		return nil, err
+	}
+	return filter.ParseProjects(f.expr, opts)
+}
+
+// developers returns the parsed -filter for developers.
+func (f *filterFlags) developers() (*filter.Filter[trending.Developer], error) {
+	opts, err := f.options()
+	if err != nil {
+		return nil, err
+	}
+	return filter.ParseDevelopers(f.expr, opts)
+}
+
+// blocklistFlags are the flags to exclude spammy or irrelevant projects.
+type blocklistFlags struct {
+	path   string
+	report bool
+}
+
+// register adds the blocklist flags to fs.
+func (f *blocklistFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.path, "blocklist", "", "YAML file with owners, name globs, keywords and heuristics of projects to exclude")
+	fs.BoolVar(&f.report, "blocklist-report", false, "print the excluded projects and the reasons to stderr")
+}
+
+// rules returns the rules of -blocklist or nil if no blocklist is set.
+func (f *blocklistFlags) rules() (*blocklist.Rules, error) {
+	if len(f.path) == 0 {
+		return nil, nil
+	}
+	return blocklist.Load(f.path)
+}
+
+// apply returns the projects passing rules and their ranks in projects. With -blocklist-report, the excluded projects are written to w.
+// If rules is nil, projects are returned unchanged and the ranks are nil, so their position is their rank.
+func (f *blocklistFlags) apply(w io.Writer, rules *blocklist.Rules, projects []trending.Project) ([]trending.Project, []int) {
+	if rules == nil {
+		return projects, nil
+	}
+	report := rules.Apply(projects)
+	if f.report {
+		for _, e := range report.Excluded {
+			reasons := make([]string, 0, len(e.Reasons))
+			for _, r := range e.Reasons {
+				reasons = append(reasons, r.String())
+			}
+			fmt.Fprintf(w, "excluded %s (rank %d): %s\n", e.Project.Name, e.Rank, strings.Join(reasons, "; "))
+		}
+	}
+	return report.Kept, report.KeptRanks
+}
+
+// envGitHubToken is the environment variable with the default of -github-token.
+const envGitHubToken = "GITHUB_TOKEN"
+
+// enrichFlags are the flags to add data of the GitHub API to the results.
+type enrichFlags struct {
+	enabled     bool
+	apiURL      string
+	token       string
+	concurrency int
+	graphQL     bool
+	graphQLURL  string
+}
+
+// register adds the enrich flags to fs.
+// If graphQL is true, the flags to look up projects at the GraphQL API are added as well.
+func (f *enrichFlags) register(fs *flag.FlagSet, graphQL bool) {
+	fs.BoolVar(&f.enabled, "enrich", false, "look up every entry at the GitHub API, e.g. for the license and topics columns of projects or the company and followers columns of developers")
+	fs.StringVar(&f.apiURL, "api-url", enrich.DefaultBaseURL, "base URL of the GitHub REST API, e.g. https://github.example.com/api/v3/ for GitHub Enterprise")
+	fs.StringVar(&f.token, "github-token", os.Getenv(envGitHubToken), "token to authenticate at the GitHub API (default $"+envGitHubToken+")")
+	fs.IntVar(&f.concurrency, "enrich-concurrency", enrich.DefaultConcurrency, "maximum number of parallel requests to the GitHub API")
+	if graphQL {
+		fs.BoolVar(&f.graphQL, "enrich-graphql", false, "like -enrich, but look up the projects in batches at the GitHub GraphQL API, which needs far less requests (requires a token)")
+		fs.StringVar(&f.graphQLURL, "graphql-url", enrich.DefaultGraphQLURL, "address of the GitHub GraphQL API, e.g. https://github.example.com/api/graphql for GitHub Enterprise")
+	}
+}
+
+// projectEnricher adds data of the GitHub API to projects, see enrich.Enricher and enrich.GraphQL.
+type projectEnricher interface {
+	Projects(ctx context.Context, projects []trending.Project) error
+}
+
+// projectEnricher creates the project enricher configured by the flags
+// or returns nil if neither -enrich nor -enrich-graphql is set.
+func (f *enrichFlags) projectEnricher(cf *clientFlags) (projectEnricher, error) {
+	if !f.graphQL {
+		e, err := f.enricher(cf)
+		if e == nil || err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+
+	if len(f.token) == 0 {
+		return nil, fmt.Errorf("-enrich-graphql requires -github-token or $%s", envGitHubToken)
+	}
+	u, err := url.Parse(f.graphQLURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GraphQL URL %q: %w", f.graphQLURL, err)
+	}
+
+	g := enrich.NewGraphQL(f.token)
+	g.Endpoint = u
+	g.Client = &http.Client{Timeout: cf.timeout}
+	return g, nil
+}
+
+// enricher creates the enricher configured by the flags or returns nil if -enrich is not set.
+// Requests use the timeout of cf.
+func (f *enrichFlags) enricher(cf *clientFlags) (*enrich.Enricher, error) {
+	if !f.enabled {
+		return nil, nil
+	}
+
+	apiURL := f.apiURL
+	if !strings.HasSuffix(apiURL, "/") {
+		apiURL += "/"
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API URL %q: %w", f.apiURL, err)
+	}
+
+	e := enrich.New(f.token)
+	e.BaseURL = u
+	e.Client = &http.Client{Timeout: cf.timeout}
+	e.Concurrency = f.concurrency
+	return e, nil
+}
+
+// listFlag is a flag that can be repeated. Every occurrence adds a value.
+type listFlag []string
+
+// String returns the values of the flag.
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set adds value.
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// newFlagSet creates a flag set for the command name which writes its output to the stderr of a.
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("go-trending "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parseFlags parses args into fs and makes sure that no positional arguments are left.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected arguments: %v\n", fs.Args())
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// runProjects implements the "projects" command.
+func runProjects(a *app, args []string) error {
+	var cf clientFlags
+	var qf queryFlags
+	var of outputFlags
+	var sf storeFlags
+	var ff filterFlags
+	var bf blocklistFlags
+	var ef enrichFlags
+	fs := a.newFlagSet("projects")
+	cf.register(fs)
+	qf.register(fs, false)
+	of.register(fs, format.ProjectColumns(), true)
+	sf.register(fs)
+	ff.register(fs, filter.ProjectFields())
+	bf.register(fs)
+	ef.register(fs, true)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	q, err := qf.query(a.stderr)
+	if err != nil {
+		return err
+	}
+	// Fail on invalid output flags before fetching
+	if _, err := of.formatter(nil); err != nil {
+		return err
+	}
+	tmpl, err := of.parseTemplate()
+	if err != nil {
+		return err
+	}
+	sel, err := ff.projects()
+	if err != nil {
+		return err
+	}
+	rules, err := bf.rules()
+	if err != nil {
+		return err
+	}
+	enricher, err := ef.projectEnricher(&cf)
+	if err != nil {
+		return err
+	}
+	trend, err := cf.newTrending()
+	if err != nil {
+		return err
+	}
+
+	projects, err := trend.GetProjectsByQuery(q)
+	if err != nil {
+		return err
+	}
+	// The previous snapshot has to be loaded before the new one is saved
+	var previous []trending.Project
+	if tmpl != nil {
+		snapshot, err := sf.previous(store.KindProjects, q)
+		if err != nil {
+			return err
+		}
+		if snapshot != nil {
+			// Compare ranks in the same selection of projects
+			previous, _ = sel.ApplyRanks(bf.apply(io.Discard, rules, snapshot.Projects))
+		}
+	}
+	fetchedAt := time.Now()
+	if err := sf.save(store.NewProjectsSnapshot(q, projects, fetchedAt)); err != nil {
+		return err
+	}
+	// The snapshot keeps the complete result, the blocklist and the filter only affect the output.
+	// The filter and the output use the ranks of the trending page.
+	projects, ranks := sel.ApplyRanks(bf.apply(a.stderr, rules, projects))
+	if enricher != nil {
+		// Missing data of a few projects is no reason to drop the whole result
+		if err := enricher.Projects(a.ctx, projects); err != nil {
+			fmt.Fprintf(a.stderr, "go-trending projects: %v\n", err)
+		}
+	}
+
+	if tmpl != nil {
+		return format.Render(a.stdout, tmpl, format.Result{
+			Query:            q,
+			Projects:         projects,
+			PreviousProjects: previous,
+			GeneratedAt:      fetchedAt,
+		})
+	}
+	f, err := of.formatter(ranks)
+	if err != nil {
+		return err
+	}
+	return f.Projects(a.stdout, projects)
+}
+
+// runDevelopers implements the "developers" command.
+func runDevelopers(a *app, args []string) error {
+	var cf clientFlags
+	var qf queryFlags
+	var of outputFlags
+	var sf storeFlags
+	var ff filterFlags
+	var ef enrichFlags
+	fs := a.newFlagSet("developers")
+	cf.register(fs)
+	qf.register(fs, true)
+	of.register(fs, format.DeveloperColumns(), true)
+	sf.register(fs)
+	ff.register(fs, filter.DeveloperFields())
+	ef.register(fs, false)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	q, err := qf.query(a.stderr)
+	if err != nil {
+		return err
+	}
+	// Fail on invalid output flags before fetching
+	if _, err := of.formatter(nil); err != nil {
+		return err
+	}
+	tmpl, err := of.parseTemplate()
+	if err != nil {
+		return err
+	}
+	sel, err := ff.developers()
+	if err != nil {
+		return err
+	}
+	enricher, err := ef.enricher(&cf)
+	if err != nil {
+		return err
+	}
+	trend, err := cf.newTrending()
+	if err != nil {
+		return err
+	}
+
+	developers, err := trend.GetDevelopersByQuery(q)
+	if err != nil {
+		return err
+	}
+	fetchedAt := time.Now()
+	if err := sf.save(store.NewDevelopersSnapshot(q, developers, fetchedAt)); err != nil {
+		return err
+	}
+	// The snapshot keeps the complete result, the filter only affects the output
+	developers, ranks := sel.ApplyRanks(developers, nil)
+	if enricher != nil {
+		// Missing data of a few developers is no reason to drop the whole result
+		if err := enricher.Developers(a.ctx, developers); err != nil {
+			fmt.Fprintf(a.stderr, "go-trending developers: %v\n", err)
+		}
+	}
+
+	if tmpl != nil {
+		return format.Render(a.stdout, tmpl, format.Result{
+			Query:       q,
+			Developers:  developers,
+			GeneratedAt: fetchedAt,
+		})
+	}
+	f, err := of.formatter(ranks)
+	if err != nil {
+		return err
+	}
+	return f.Developers(a.stdout, developers)
+}
+
+// runLanguages implements the "languages" command.
+func runLanguages(a *app, args []string) error {
+	var cf clientFlags
+	var of outputFlags
+	var popular bool
+	fs := a.newFlagSet("languages")
+	cf.register(fs)
+	of.register(fs, format.LanguageColumns(), false)
+	fs.BoolVar(&popular, "popular", false, "only list the popular languages shown on top of the language dropdown")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	f, err := of.formatter(nil)
+	if err != nil {
+		return err
+	}
+	trend, err := cf.newTrending()
+	if err != nil {
+		return err
+	}
+
+	getLanguages := trend.GetLanguages
+	if popular {
+		getLanguages = trend.GetPopularLanguages
+	}
+	languages, err := getLanguages()
+	if err != nil {
+		return err
+	}
+
+	return f.Languages(a.stdout, languages)
+}
+
+// runSpokenLanguages implements the "spoken-languages" command.
+func runSpokenLanguages(a *app, args []string) error {
+	var cf clientFlags
+	fs := a.newFlagSet("spoken-languages")
+	cf.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	trend, err := cf.newTrending()
+	if err != nil {
+		return err
+	}
+
+	spokenLanguages, err := trend.GetSpokenLanguages()
+	if err != nil {
+		return err
+	}
+
+	for index, spokenLanguage := range spokenLanguages {
+		i := index + 1
+		fmt.Fprintf(a.stdout, "%d: %s (%s)\n", i, spokenLanguage.Name, spokenLanguage.Code)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/diff"
+	"github.com/andygrunwald/go-trending/store"
+)
+
+// runDiff implements the "diff" command.
+//
+// It compares either two JSON files written by "projects -format json" (or "developers -format json")
+// or the two latest snapshots of a query in a database written with -store.
+func runDiff(a *app, args []string) error {
+	var (
+		developers bool
+		dbPath     string
+		output     string
+		qf         queryFlags
+	)
+	fs := a.newFlagSet("diff")
+	fs.BoolVar(&developers, "developers", false, "compare developers instead of projects")
+	fs.StringVar(&dbPath, "store", "", "compare the two latest snapshots of the query in this database file instead of two JSON files")
+	fs.StringVar(&output, "format", "text", "output format: text or json")
+	qf.register(fs, false)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: go-trending diff [flags] old.json new.json")
+		fmt.Fprintln(fs.Output(), "       go-trending diff -store trending.db [flags]")
+		fmt.Fprintln(fs.Output(), "")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+
+	wantArgs := 2
+	if len(dbPath) > 0 {
+		wantArgs = 0
+	}
+	if fs.NArg() != wantArgs {
+		fs.Usage()
+		return errUsage
+	}
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid format %q, expected text or json", output)
+	}
+
+	var result any
+	if developers {
+		old, new, err := loadDevelopers(dbPath, qf, fs.Args(), a.stderr)
+		if err != nil {
+			return err
+		}
+		result = diff.Developers(old, new)
+	} else {
+		old, new, err := loadProjects(dbPath, qf, fs.Args(), a.stderr)
+		if err != nil {
+			return err
+		}
+		result = diff.Projects(old, new)
+	}
+
+	if output == "json" {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	switch d := result.(type) {
+	case diff.ProjectsDiff:
+		writeProjectsDiff(a.stdout, d)
+	case diff.DevelopersDiff:
+		writeDevelopersDiff(a.stdout, d)
+	}
+	return nil
+}
+
+// loadProjects returns the old and the new projects, either from the database dbPath or from the JSON files in files.
+func loadProjects(dbPath string, qf queryFlags, files []string, stderr io.Writer) ([]trending.Project, []trending.Project, error) {
+	if len(dbPath) > 0 {
+		old, new, err := latestSnapshots(dbPath, store.KindProjects, qf, stderr)
+		if err != nil {
+			return nil, nil, err
+		}
+		return old.Projects, new.Projects, nil
+	}
+
+	var old, new []trending.Project
+	if err := readJSONFile(files[0], &old); err != nil {
+		return nil, nil, err
+	}
+	if err := readJSONFile(files[1], &new); err != nil {
+		return nil, nil, err
+	}
+	return old, new, nil
+}
+
+// loadDevelopers returns the old and the new developers, either from the database dbPath or from the JSON files in files.
+func loadDevelopers(dbPath string, qf queryFlags, files []string, stderr io.Writer) ([]trending.Developer, []trending.Developer, error) {
+	if len(dbPath) > 0 {
+		old, new, err := latestSnapshots(dbPath, store.KindDevelopers, qf, stderr)
+		if err != nil {
+			return nil, nil, err
+		}
+		return old.Developers, new.Developers, nil
+	}
+
+	var old, new []trending.Developer
+	if err := readJSONFile(files[0], &old); err != nil {
+		return nil, nil, err
+	}
+	if err := readJSONFile(files[1], &new); err != nil {
+		return nil, nil, err
+	}
+	return old, new, nil
+}
+
+// latestSnapshots returns the two latest snapshots of kind matching the query flags.
+// Warnings about unknown languages are written to stderr.
+func latestSnapshots(dbPath, kind string, qf queryFlags, stderr io.Writer) (*store.Snapshot, *store.Snapshot, error) {
+	q, err := qf.query(stderr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := store.Open(dbPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+
+	snapshots, err := db.Snapshots(store.Filter{
+		Kind:           kind,
+		Since:          q.Since,
+		Language:       q.Language,
+		SpokenLanguage: q.SpokenLanguage,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(snapshots) < 2 {
+		return nil, nil, fmt.Errorf("found %d snapshot(s) of %s for the query, need at least 2", len(snapshots), kind)
+	}
+
+	n := len(snapshots)
+	return &snapshots[n-2], &snapshots[n-1], nil
+}
+
+// readJSONFile decodes the JSON file filename into v.
+func readJSONFile(filename string, v any) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := json.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("decoding %s: %w", filename, err)
+	}
+	return nil
+}
+
+// writeProjectsDiff writes d as human readable text into w.
+func writeProjectsDiff(w io.Writer, d diff.ProjectsDiff) {
+	if !d.Changed() {
+		fmt.Fprintln(w, "No changes.")
+		return
+	}
+
+	for _, c := range d.Entered {
+		fmt.Fprintf(w, "+ %s entered at rank %d (%d stars)\n", c.Project.Name, c.NewRank, c.NewStars)
+	}
+	for _, c := range d.Left {
+		fmt.Fprintf(w, "- %s left from rank %d\n", c.Project.Name, c.OldRank)
+	}
+	for _, c := range d.MovedUp {
+		fmt.Fprintf(w, "↑ %s moved up from rank %d to %d (%+d stars)\n", c.Project.Name, c.OldRank, c.NewRank, c.StarsDelta())
+	}
+	for _, c := range d.MovedDown {
+		fmt.Fprintf(w, "↓ %s moved down from rank %d to %d (%+d stars)\n", c.Project.Name, c.OldRank, c.NewRank, c.StarsDelta())
+	}
+}
+
+// writeDevelopersDiff writes d as human readable text into w.
+func writeDevelopersDiff(w io.Writer, d diff.DevelopersDiff) {
+	if !d.Changed() {
+		fmt.Fprintln(w, "No changes.")
+		return
+	}
+
+	for _, c := range d.Entered {
+		fmt.Fprintf(w, "+ %s entered at rank %d\n", c.Developer.DisplayName, c.NewRank)
+	}
+	for _, c := range d.Left {
+		fmt.Fprintf(w, "- %s left from rank %d\n", c.Developer.DisplayName, c.OldRank)
+	}
+	for _, c := range d.MovedUp {
+		fmt.Fprintf(w, "↑ %s moved up from rank %d to %d\n", c.Developer.DisplayName, c.OldRank, c.NewRank)
+	}
+	for _, c := range d.MovedDown {
+		fmt.Fprintf(w, "↓ %s moved down from rank %d to %d\n", c.Developer.DisplayName, c.OldRank, c.NewRank)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-trending/feed"
+)
+
+// runFeed implements the "feed" command.
+func runFeed(a *app, args []string) error {
+	var (
+		cf         clientFlags
+		qf         queryFlags
+		developers bool
+		typ        string
+		opts       feed.Options
+	)
+	fs := a.newFlagSet("feed")
+	cf.register(fs)
+	qf.register(fs, false)
+	fs.BoolVar(&qf.sponsorable, "sponsorable", false, "only list developers who can be sponsored (with -developers)")
+	fs.BoolVar(&developers, "developers", false, "create a feed of trending developers instead of projects")
+	fs.StringVar(&typ, "type", feed.Atom, "feed type: "+strings.Join(feed.Formats(), ", "))
+	fs.StringVar(&opts.Title, "title", "", "title of the feed (default derived from the query)")
+	fs.StringVar(&opts.FeedURL, "feed-url", "", "address the feed will be published at")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	q, err := qf.query(a.stderr)
+	if err != nil {
+		return err
+	}
+	if len(feed.ContentType(typ)) == 0 {
+		return fmt.Errorf("invalid feed type %q, expected one of %s", typ, strings.Join(feed.Formats(), ", "))
+	}
+	trend, err := cf.newTrending()
+	if err != nil {
+		return err
+	}
+	opts.BaseURL = trend.BaseURL
+
+	if developers {
+		result, err := trend.FetchDevelopers(q)
+		if err != nil {
+			return err
+		}
+		return feed.Developers(a.stdout, typ, result, opts)
+	}
+
+	result, err := trend.FetchProjects(q)
+	if err != nil {
+		return err
+	}
+	return feed.Projects(a.stdout, typ, result, opts)
+}
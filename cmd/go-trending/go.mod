@@ -0,0 +1,31 @@
+module github.com/andygrunwald/go-trending/cmd/go-trending
+
+go 1.23.0
+
+require (
+	github.com/andygrunwald/go-trending v0.0.0
+	github.com/andygrunwald/go-trending/metrics v0.0.0
+)
+
+require (
+	github.com/PuerkitoBio/goquery v1.10.3 // indirect
+	github.com/andybalholm/cascadia v1.3.3 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_golang v1.22.0 // indirect
+	github.com/prometheus/client_model v0.6.1 // indirect
+	github.com/prometheus/common v0.62.0 // indirect
+	github.com/prometheus/procfs v0.15.1 // indirect
+	go.etcd.io/bbolt v1.4.3 // indirect
+	golang.org/x/net v0.39.0 // indirect
+	golang.org/x/sys v0.32.0 // indirect
+	google.golang.org/protobuf v1.36.5 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The command is developed together with the main module and the Prometheus implementation.
+replace (
+	github.com/andygrunwald/go-trending => ../../
+	github.com/andygrunwald/go-trending/metrics => ../../metrics
+)
@@ -0,0 +1,134 @@
+// Command go-trending prints trending repositories, developers and languages from GitHub.
+//
+// Usage:
+//
+//	go-trending <command> [flags]
+//
+// The commands are:
+//
+//	projects          list trending repositories
+//	developers        list trending developers
+//	languages         list programing languages available for filtering
+//	spoken-languages  list spoken languages available for filtering
+//	diff              compare two trending results
+//	stats             compute metrics over stored trending results
+//	watch             poll trending and print changes as NDJSON
+//	check             report trending projects and developers of a watchlist
+//	feed              print trending as RSS, Atom or JSON Feed
+//	serve             serve trending as JSON REST API
+//
+// Run "go-trending <command> -h" to see the flags of a command.
+// All commands accept -base-url to talk to a GitHub Enterprise instance.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// command is a single subcommand of go-trending.
+type command struct {
+	// name is the name of the command as typed on the command line, like "projects".
+	name string
+
+	// description is a one line summary shown in the usage.
+	description string
+
+	// run executes the command with the remaining arguments.
+	run func(a *app, args []string) error
+}
+
+// app bundles everything a command needs to interact with the outside world.
+// Tests swap stdout and stderr to capture the output.
+type app struct {
+	// ctx is canceled when the process receives SIGINT or SIGTERM.
+	// Long running commands like "watch" stop gracefully afterwards.
+	ctx context.Context
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// commands is the list of all known subcommands in the order they are shown in the usage.
+var commands = []command{
+	{name: "projects", description: "list trending repositories", run: runProjects},
+	{name: "developers", description: "list trending developers", run: runDevelopers},
+	{name: "languages", description: "list programing languages available for filtering", run: runLanguages},
+	{name: "spoken-languages", description: "list spoken languages available for filtering", run: runSpokenLanguages},
+	{name: "diff", description: "compare two trending results", run: runDiff},
+	{name: "stats", description: "compute metrics over stored trending results", run: runStats},
+	{name: "watch", description: "poll trending and print changes as NDJSON", run: runWatch},
+	{name: "check", description: "report trending projects and developers of a watchlist", run: runCheck},
+	{name: "feed", description: "print trending as RSS, Atom or JSON Feed", run: runFeed},
+	{name: "serve", description: "serve trending as JSON REST API", run: runServe},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command given in args and returns the exit code of the process.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	a := &app{
+		ctx:    ctx,
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	if len(args) == 0 {
+		a.usage()
+		return 2
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		a.usage()
+		return 0
+	}
+
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+
+		err := c.run(a, args[1:])
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		case errors.Is(err, errMatched):
+			return 3
+		default:
+			fmt.Fprintf(stderr, "go-trending %s: %v\n", name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(stderr, "go-trending: unknown command %q\n", name)
+	a.usage()
+	return 2
+}
+
+// usage prints the list of all commands.
+func (a *app) usage() {
+	fmt.Fprintln(a.stderr, "Usage: go-trending <command> [flags]")
+	fmt.Fprintln(a.stderr, "")
+	fmt.Fprintln(a.stderr, "The commands are:")
+	for _, c := range commands {
+		fmt.Fprintf(a.stderr, "  %-18s %s\n", c.name, c.description)
+	}
+	fmt.Fprintln(a.stderr, "")
+	fmt.Fprintln(a.stderr, `Run "go-trending <command> -h" to see the flags of a command.`)
+}
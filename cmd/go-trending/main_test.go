@@ -0,0 +1,684 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// newTestServer starts a test HTTP server that answers like github.com with the fixtures of the testdata folder.
+// Every request is recorded in requests.
+func newTestServer(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.String())
+		http.ServeFile(w, r, "../../testdata/github.com_trending.html")
+	})
+	mux.HandleFunc("/trending/developers", func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.String())
+		http.ServeFile(w, r, "../../testdata/github.com_trending_developers.html")
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// runCommand executes go-trending with args and returns the exit code, stdout and stderr.
+func runCommand(args ...string) (int, string, string) {
+	return runCommandContext(context.Background(), args...)
+}
+
+// runCommandContext executes go-trending with args until ctx is canceled and returns the exit code, stdout and stderr.
+func runCommandContext(ctx context.Context, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(ctx, args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Projects(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, stdout, stderr := runCommand("projects", "-base-url", server.URL, "-since", "weekly", "-language", "go", "-spoken-language", "en")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	want := []string{"/trending?l=go&since=weekly&spoken_language_code=en"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("projects requested %v, want %v", requests, want)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) < 26 {
+		t.Errorf("projects printed %d lines, want at least 26", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "RANK ") {
+		t.Errorf("projects printed %q as first line, want the table header", lines[0])
+	}
+}
+
+func TestRun_LanguageAlias(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, _, stderr := runCommand("projects", "-base-url", server.URL, "-language", "Golang")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	want := []string{"/trending?l=go&since=daily"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("projects requested %v, want %v", requests, want)
+	}
+
+	// Unknown languages that look like URL names are requested anyway, after a warning
+	code, _, stderr = runCommand("projects", "-base-url", server.URL, "-language", "golan")
+	if code != 0 || !strings.Contains(stderr, `did you mean "go"`) {
+		t.Errorf("projects with an unknown language returned exit code %d and %q, want 0 with a suggestion", code, stderr)
+	}
+	want = append(want, "/trending?l=golan&since=daily")
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("projects requested %v, want %v", requests, want)
+	}
+
+	code, _, stderr = runCommand("projects", "-base-url", server.URL, "-language", "go/../..")
+	if code != 1 || !strings.Contains(stderr, `unknown language "go/../.."`) {
+		t.Errorf("projects with an invalid language returned exit code %d and %q, want 1", code, stderr)
+	}
+	if len(requests) != len(want) {
+		t.Errorf("projects with an invalid language requested GitHub")
+	}
+}
+
+func TestRun_Developers(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, stdout, stderr := runCommand("developers", "-base-url", server.URL, "-sponsorable")
+	if code != 0 {
+		t.Fatalf("developers returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	want := []string{"/trending/developers?since=daily&sponsorable=1"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("developers requested %v, want %v", requests, want)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) < 26 {
+		t.Errorf("developers printed %d lines, want at least 26", len(lines))
+	}
+}
+
+func TestRun_Languages(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, stdout, stderr := runCommand("languages", "-base-url", server.URL)
+	if code != 0 {
+		t.Fatalf("languages returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	if !strings.Contains(stdout, "\nGo ") {
+		t.Errorf("languages printed no line for Go, got %s", stdout)
+	}
+
+	code, stdout, stderr = runCommand("languages", "-base-url", server.URL, "-popular")
+	if code != 0 {
+		t.Fatalf("languages -popular returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, "\nGo ") || strings.Contains(stdout, "ABAP") {
+		t.Errorf("languages -popular printed %s, want popular languages like Go only", stdout)
+	}
+}
+
+func TestRun_SpokenLanguages(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, stdout, stderr := runCommand("spoken-languages", "-base-url", server.URL)
+	if code != 0 {
+		t.Fatalf("spoken-languages returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	if !strings.Contains(stdout, ": English (en)\n") {
+		t.Errorf("spoken-languages printed no line for English, got %s", stdout)
+	}
+}
+
+func TestRun_ProjectsFormat(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, stdout, stderr := runCommand("projects", "-base-url", server.URL, "-format", "csv", "-columns", "name,stars", "-sort", "-stars")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if lines[0] != "name,stars" {
+		t.Errorf("projects printed %q as CSV header, want %q", lines[0], "name,stars")
+	}
+	if lines[1] != "public-apis/public-apis,240084" {
+		t.Errorf("projects printed %q as first CSV line, want %q", lines[1], "public-apis/public-apis,240084")
+	}
+}
+
+func TestRun_ProjectsFilter(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	blocklist := filepath.Join(t.TempDir(), "blocklist.txt")
+	if err := os.WriteFile(blocklist, []byte("# spam\npublic-apis\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCommand("projects", "-base-url", server.URL, "-format", "csv", "-columns", "name,stars", "-sort", "-stars",
+		"-filter", `stars > 20000 && !owner in blocklist`, "-list", "blocklist="+blocklist)
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	want := []string{"name,stars", "airbnb/javascript,134008", "microsoft/playwright,50943", "Yidadaa/ChatGPT-Next-Web,26519", "geekan/HowToLiveLonger,24482"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("projects printed %q, want %q", lines, want)
+	}
+
+	// The rank column shows the ranks of the trending page, not the positions after filtering
+	code, stdout, stderr = runCommand("projects", "-base-url", server.URL, "-format", "csv", "-columns", "rank,name", "-filter", `language == "Python" && rank <= 10`)
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	lines = strings.Split(strings.TrimSpace(stdout), "\n")
+	want = []string{"rank,name", "1,smol-ai/developer", "2,StanGirard/quivr", "10,Gioman101/FlipperAmiibo"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("projects printed %q, want %q", lines, want)
+	}
+
+	code, _, stderr = runCommand("projects", "-base-url", server.URL, "-filter", `stars > "many"`)
+	if code != 1 || !strings.Contains(stderr, "expected a number") {
+		t.Errorf("projects with invalid filter returned exit code %d and %q, want 1 and a parse error", code, stderr)
+	}
+	if len(requests) != 2 {
+		t.Errorf("projects with invalid filter requested %d pages, want none", len(requests)-2)
+	}
+}
+
+func TestRun_ProjectsBlocklist(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	rules := filepath.Join(t.TempDir(), "blocklist.yaml")
+	if err := os.WriteFile(rules, []byte("owners: [public-apis]\nnames: [\"microsoft/*\"]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCommand("projects", "-base-url", server.URL, "-format", "csv", "-columns", "name", "-blocklist", rules, "-blocklist-report")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 1+25-3 {
+		t.Errorf("projects printed %d lines, want the header and 22 projects", len(lines))
+	}
+	want := `excluded microsoft/guidance (rank 4): name "microsoft/*"
+excluded microsoft/playwright (rank 18): name "microsoft/*"
+excluded public-apis/public-apis (rank 25): owner "public-apis"
+`
+	if stderr != want {
+		t.Errorf("projects reported %q, want %q", stderr, want)
+	}
+
+	// The rank column and -filter use the ranks of the trending page, not the positions after the blocklist
+	code, stdout, stderr = runCommand("projects", "-base-url", server.URL, "-format", "csv", "-columns", "rank,name", "-blocklist", rules, "-filter", "rank <= 5")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	lines = strings.Split(strings.TrimSpace(stdout), "\n")
+	wantLines := []string{"rank,name", "1,smol-ai/developer", "2,StanGirard/quivr", "3,sunner/ChatALL", "5,langgenius/dify"}
+	if !reflect.DeepEqual(lines, wantLines) {
+		t.Errorf("projects printed %q, want %q", lines, wantLines)
+	}
+}
+
+func TestRun_ProjectsEnrich(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	var authorization []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		if r.URL.Path == "/api/v3/repos/airbnb/javascript" {
+			w.Write([]byte(`{"license": {"spdx_id": "MIT"}, "topics": ["javascript", "style-guide"]}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(api.Close)
+
+	code, stdout, stderr := runCommand("projects", "-base-url", server.URL, "-format", "csv", "-columns", "name,license,topics",
+		"-filter", `owner in ("airbnb", "microsoft")`, "-enrich", "-api-url", api.URL+"/api/v3", "-github-token", "s3cr3t", "-enrich-concurrency", "1")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	want := "name,license,topics\n" +
+		"microsoft/guidance,,\n" +
+		"airbnb/javascript,MIT,\"javascript,style-guide\"\n" +
+		"microsoft/playwright,,\n"
+	if stdout != want {
+		t.Errorf("projects printed %q, want %q", stdout, want)
+	}
+	if !strings.Contains(stderr, "microsoft/guidance") || !strings.Contains(stderr, "404") {
+		t.Errorf("projects reported %q, want the failed lookups", stderr)
+	}
+	if !reflect.DeepEqual(authorization, []string{"Bearer s3cr3t", "Bearer s3cr3t", "Bearer s3cr3t"}) {
+		t.Errorf("projects sent the authorizations %q, want 3 with the token", authorization)
+	}
+}
+
+func TestRun_ProjectsEnrichGraphQL(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	var queries int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries++
+		var req struct {
+			Variables map[string]string `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		data := map[string]any{}
+		for i := 0; i < len(req.Variables)/2; i++ {
+			alias := "r" + strconv.Itoa(i)
+			if owner := req.Variables["o"+strconv.Itoa(i)]; owner == "airbnb" {
+				data[alias] = map[string]any{"licenseInfo": map[string]string{"spdxId": "MIT"}, "stargazerCount": 120000}
+			} else {
+				data[alias] = map[string]any{"licenseInfo": nil, "stargazerCount": 1}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(api.Close)
+
+	code, stdout, stderr := runCommand("projects", "-base-url", server.URL, "-format", "csv", "-columns", "name,license,total_stars",
+		"-filter", `owner in ("airbnb", "microsoft")`, "-enrich-graphql", "-graphql-url", api.URL+"/api/graphql", "-github-token", "s3cr3t")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	want := "name,license,total_stars\n" +
+		"microsoft/guidance,,1\n" +
+		"airbnb/javascript,MIT,120000\n" +
+		"microsoft/playwright,,1\n"
+	if stdout != want {
+		t.Errorf("projects printed %q, want %q", stdout, want)
+	}
+	if queries != 1 {
+		t.Errorf("projects sent %d GraphQL queries, want 1", queries)
+	}
+
+	// The GraphQL API doesn't allow anonymous requests
+	code, _, stderr = runCommand("projects", "-base-url", server.URL, "-enrich-graphql", "-github-token", "")
+	if code != 1 || !strings.Contains(stderr, "requires -github-token") {
+		t.Errorf("projects without token returned exit code %d and %q, want 1 and the missing token", code, stderr)
+	}
+}
+
+func TestRun_DevelopersEnrich(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		login := strings.TrimPrefix(r.URL.Path, "/users/")
+		w.Write([]byte(`{"company": "@` + login + `-inc", "followers": ` + strconv.Itoa(len(login)) + `}`))
+	}))
+	t.Cleanup(api.Close)
+
+	code, stdout, stderr := runCommand("developers", "-base-url", server.URL, "-format", "csv", "-columns", "display_name,company,followers",
+		"-filter", "rank <= 2", "-enrich", "-api-url", api.URL)
+	if code != 0 {
+		t.Fatalf("developers returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	want := "display_name,company,followers\nRich,@Rich-Harris-inc,11\nBjerg,@onbjerg-inc,7\n"
+	if stdout != want {
+		t.Errorf("developers printed %q, want %q", stdout, want)
+	}
+}
+
+func TestRun_ProjectsTemplate(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, stdout, stderr := runCommand("projects", "-base-url", server.URL, "-template", "slack")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, ":chart_with_upwards_trend: *Trending on GitHub today*") {
+		t.Errorf("projects printed %q, want the slack template", stdout)
+	}
+
+	file := filepath.Join(t.TempDir(), "names.tmpl")
+	if err := os.WriteFile(file, []byte(`{{ range .Projects }}{{ .Name }}{{ "\n" }}{{ end }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr = runCommand("projects", "-base-url", server.URL, "-template", file)
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "smol-ai/developer\n") {
+		t.Errorf("projects printed %q, want the project names", stdout)
+	}
+
+	// rankDelta compares with the previous snapshot of -store
+	if err := os.WriteFile(file, []byte(`{{ range .Projects }}{{ .Name }} [{{ rankDelta . }}]{{ "\n" }}{{ end }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	db := filepath.Join(t.TempDir(), "trending.db")
+	for _, want := range []string{"smol-ai/developer []\n", "smol-ai/developer [=]\n"} {
+		code, stdout, stderr = runCommand("projects", "-base-url", server.URL, "-template", file, "-store", db)
+		if code != 0 {
+			t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+		}
+		if !strings.HasPrefix(stdout, want) {
+			t.Errorf("projects printed %q, want prefix %q", stdout, want)
+		}
+	}
+}
+
+func TestRun_Feed(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, stdout, stderr := runCommand("feed", "-base-url", server.URL, "-type", "rss", "-since", "weekly")
+	if code != 0 {
+		t.Fatalf("feed returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, `<rss version="2.0">`) || !strings.Contains(stdout, "<title>smol-ai/developer</title>") {
+		t.Errorf("feed printed %q, want an RSS document with the projects", stdout)
+	}
+	if want := "<link>" + server.URL + "/trending?since=weekly</link>"; !strings.Contains(stdout, want) {
+		t.Errorf("feed printed %q, want the link %s of -base-url", stdout, want)
+	}
+
+	code, stdout, stderr = runCommand("feed", "-base-url", server.URL, "-type", "json", "-developers", "-sponsorable")
+	if code != 0 {
+		t.Fatalf("feed -developers returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, `"version": "https://jsonfeed.org/version/1.1"`) || !strings.Contains(stdout, "Rich-Harris") {
+		t.Errorf("feed -developers printed %q, want a JSON Feed with the developers", stdout)
+	}
+	if want := "/trending/developers?since=daily&sponsorable=1"; requests[len(requests)-1] != want {
+		t.Errorf("feed -developers requested %s, want %s", requests[len(requests)-1], want)
+	}
+}
+
+func TestRun_LogLevel(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, _, stderr := runCommand("projects", "-base-url", server.URL, "-log-level", "debug")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stderr, `level=DEBUG msg="received response" page=projects`) || !strings.Contains(stderr, "status=200") {
+		t.Errorf("projects logged %q, want the response at debug level", stderr)
+	}
+
+	code, _, stderr = runCommand("projects", "-base-url", server.URL)
+	if code != 0 || len(stderr) > 0 {
+		t.Errorf("projects without -log-level returned exit code %d and logged %q, want 0 and nothing", code, stderr)
+	}
+}
+
+func TestRun_InvalidUsage(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{args: nil, code: 2},
+		{args: []string{"unknown"}, code: 2},
+		{args: []string{"projects", "-unknown-flag"}, code: 2},
+		{args: []string{"projects", "positional"}, code: 2},
+		{args: []string{"developers", "-spoken-language", "en"}, code: 2},
+		{args: []string{"projects", "-since", "yearly"}, code: 1},
+		{args: []string{"projects", "-format", "xml"}, code: 1},
+		{args: []string{"projects", "-template", "does-not-exist.tmpl"}, code: 1},
+		{args: []string{"diff", "only-one.json"}, code: 2},
+		{args: []string{"watch", "-jitter", "1"}, code: 2},
+		{args: []string{"watch", "-jitter", "-0.1"}, code: 2},
+		{args: []string{"feed", "-type", "opml"}, code: 1},
+		{args: []string{"serve", "-addr", "invalid-address"}, code: 1},
+		{args: []string{"languages", "-log-level", "verbose"}, code: 1},
+		{args: []string{"help"}, code: 0},
+		{args: []string{"projects", "-h"}, code: 0},
+	}
+
+	for _, tt := range tests {
+		code, _, _ := runCommand(tt.args...)
+		if code != tt.code {
+			t.Errorf("go-trending %v returned exit code %d, want %d", tt.args, code, tt.code)
+		}
+	}
+}
+
+func TestRun_DiffFiles(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	code, stdout, stderr := runCommand("projects", "-base-url", server.URL, "-format", "json")
+	if code != 0 {
+		t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	var projects []trending.Project
+	if err := json.Unmarshal([]byte(stdout), &projects); err != nil {
+		t.Fatalf("Decoding projects failed: %v", err)
+	}
+
+	// The new result swaps the first and the third project and misses the last one
+	n := len(projects)
+	newProjects := append([]trending.Project{projects[2], projects[1], projects[0]}, projects[3:n-1]...)
+
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "old.json")
+	newFile := filepath.Join(dir, "new.json")
+	writeJSONFile(t, oldFile, projects)
+	writeJSONFile(t, newFile, newProjects)
+
+	code, stdout, stderr = runCommand("diff", oldFile, newFile)
+	if code != 0 {
+		t.Fatalf("diff returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	want := "- " + projects[n-1].Name + " left from rank " + strconv.Itoa(n) + "\n" +
+		"↑ " + projects[2].Name + " moved up from rank 3 to 1 (+0 stars)\n" +
+		"↓ " + projects[0].Name + " moved down from rank 1 to 3 (+0 stars)\n"
+	if stdout != want {
+		t.Errorf("diff printed %q, want %q", stdout, want)
+	}
+}
+
+func TestRun_DiffStore(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+	db := filepath.Join(t.TempDir(), "trending.db")
+
+	code, _, stderr := runCommand("diff", "-store", db)
+	if code != 1 || !strings.Contains(stderr, "need at least 2") {
+		t.Errorf("diff without snapshots returned exit code %d and %q, want 1 and an error about missing snapshots", code, stderr)
+	}
+
+	for i := 0; i < 2; i++ {
+		code, _, stderr := runCommand("projects", "-base-url", server.URL, "-language", "go", "-store", db)
+		if code != 0 {
+			t.Fatalf("projects returned exit code %d, want 0 (stderr: %s)", code, stderr)
+		}
+	}
+
+	code, stdout, stderr := runCommand("diff", "-store", db, "-language", "go")
+	if code != 0 {
+		t.Fatalf("diff returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	if stdout != "No changes.\n" {
+		t.Errorf("diff printed %q, want %q", stdout, "No changes.\n")
+	}
+}
+
+func TestRun_Stats(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+	db := filepath.Join(t.TempDir(), "trending.db")
+
+	for _, command := range []string{"projects", "projects", "developers"} {
+		code, _, stderr := runCommand(command, "-base-url", server.URL, "-language", "go", "-store", db)
+		if code != 0 {
+			t.Fatalf("%s returned exit code %d, want 0 (stderr: %s)", command, code, stderr)
+		}
+	}
+
+	code, stdout, stderr := runCommand("stats", "-store", db, "-limit", "3")
+	if code != 0 {
+		t.Fatalf("stats returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "RANK ") {
+		t.Errorf("stats printed %q, want a header and 3 projects", stdout)
+	}
+
+	code, stdout, stderr = runCommand("stats", "-store", db, "-developers", "-format", "json")
+	if code != 0 {
+		t.Fatalf("stats -developers returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	var developers []struct {
+		Appearances int      `json:"appearances"`
+		Languages   []string `json:"languages"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &developers); err != nil {
+		t.Fatalf("stats -developers printed invalid JSON: %v", err)
+	}
+	if len(developers) != 25 || developers[0].Appearances != 1 || !reflect.DeepEqual(developers[0].Languages, []string{"go"}) {
+		t.Errorf("stats -developers printed %+v, want 25 developers trending once for go", developers)
+	}
+
+	if code, _, _ := runCommand("stats"); code != 2 {
+		t.Errorf("stats without -store returned exit code %d, want 2", code)
+	}
+}
+
+// writeJSONFile encodes v as JSON into the file filename.
+func writeJSONFile(t *testing.T, filename string, v any) {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filename, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun_Watch(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	code, stdout, stderr := runCommandContext(ctx, "watch", "-base-url", server.URL, "-language", "go,rust", "-developers", "-interval", "10ms", "-duration", "1s")
+	if code != 0 {
+		t.Fatalf("watch returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+
+	// The fixtures never change, so there are no events
+	if len(stdout) > 0 {
+		t.Errorf("watch printed %q, want no events", stdout)
+	}
+
+	want := []string{
+		"/trending?l=go&since=daily",
+		"/trending/developers?l=go&since=daily",
+		"/trending?l=rust&since=daily",
+		"/trending/developers?l=rust&since=daily",
+	}
+	if len(requests) < len(want) {
+		t.Fatalf("watch requested %v, want at least one poll of %v", requests, want)
+	}
+	if !reflect.DeepEqual(requests[:len(want)], want) {
+		t.Errorf("watch requested %v in the first poll, want %v", requests[:len(want)], want)
+	}
+}
+
+func TestRun_Check(t *testing.T) {
+	var requests []string
+	server := newTestServer(t, &requests)
+
+	dir := t.TempDir()
+	matching := filepath.Join(dir, "matching.yaml")
+	if err := os.WriteFile(matching, []byte("repositories: [airbnb/javascript]\nowners: [\"micro*\"]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCommand("check", "-base-url", server.URL, "-watchlist", matching, "-language", "go")
+	if code != 3 {
+		t.Fatalf("check returned exit code %d, want 3 (stderr: %s)", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	want := []string{
+		"RANK  NAME                  STARS  RULE        PATTERN            SINCE  LANGUAGE",
+		"4     microsoft/guidance    2268   owner       micro*             daily  go",
+		"8     airbnb/javascript     67     repository  airbnb/javascript  daily  go",
+		"18    microsoft/playwright  230    owner       micro*             daily  go",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("check printed %q, want %q", lines, want)
+	}
+
+	other := filepath.Join(dir, "other.yaml")
+	if err := os.WriteFile(other, []byte("developers: [nobody]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, stderr = runCommand("check", "-base-url", server.URL, "-watchlist", other, "-developers", "-format", "json")
+	if code != 0 {
+		t.Fatalf("check without matches returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	if strings.TrimSpace(stdout) != "[]" {
+		t.Errorf("check without matches printed %q, want []", stdout)
+	}
+
+	if code, _, _ := runCommand("check"); code != 2 {
+		t.Errorf("check without -watchlist returned exit code %d, want 2", code)
+	}
+}
+
+func TestRun_Serve(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	code, _, stderr := runCommandContext(ctx, "serve", "-addr", "127.0.0.1:0", "-cache-ttl", "1m", "-cors-origin", "https://dashboard.example.com")
+	if code != 0 {
+		t.Fatalf("serve returned exit code %d, want 0 (stderr: %s)", code, stderr)
+	}
+	if !strings.HasPrefix(stderr, "Listening on 127.0.0.1:") {
+		t.Errorf("serve printed %q, want the listening address", stderr)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/andygrunwald/go-trending/metrics"
+	"github.com/andygrunwald/go-trending/server"
+)
+
+// shutdownTimeout is the time running requests get to finish after the process was interrupted.
+const shutdownTimeout = 10 * time.Second
+
+// runServe implements the "serve" command.
+//
+// It serves the REST API of the server package until the process is interrupted.
+func runServe(a *app, args []string) error {
+	var (
+		cf           clientFlags
+		addr         string
+		opts         server.Options
+		origins      []string
+		withMetrics  bool
+		projectStars bool
+	)
+	fs := a.newFlagSet("serve")
+	cf.register(fs)
+	fs.StringVar(&addr, "addr", ":8080", "address to listen on")
+	fs.DurationVar(&opts.CacheTTL, "cache-ttl", server.DefaultCacheTTL, "time to cache pages fetched from GitHub; negative disables caching")
+	fs.Func("cors-origin", "origin allowed to call the API from a browser, e.g. https://dashboard.example.com; can be repeated (default all origins)", func(s string) error {
+		origins = append(origins, s)
+		return nil
+	})
+	fs.StringVar(&opts.PublicURL, "public-url", "", "external address of the server, used for the self links of feeds")
+	fs.BoolVar(&withMetrics, "metrics", true, "expose Prometheus metrics at /metrics")
+	fs.BoolVar(&projectStars, "metrics-stars", false, "expose the stars of every trending repository as metric (one series per repository)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	opts.AllowedOrigins = origins
+
+	trend, err := cf.newTrending()
+	if err != nil {
+		return err
+	}
+	if withMetrics {
+		m := metrics.NewPrometheus(metrics.Options{ProjectStars: projectStars})
+		trend.Metrics = m
+		opts.MetricsHandler = m.Handler()
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Handler:           server.New(trend, opts),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(ln)
+	}()
+	fmt.Fprintf(a.stderr, "Listening on %s\n", ln.Addr())
+
+	select {
+	case err := <-errc:
+		return err
+	case <-a.ctx.Done():
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/andygrunwald/go-trending/analytics"
+	"github.com/andygrunwald/go-trending/store"
+)
+
+// runStats implements the "stats" command.
+//
+// It computes metrics like days on trending, best rank, star velocity and momentum
+// over the snapshots of a query in a database written with -store.
+func runStats(a *app, args []string) error {
+	var (
+		dbPath     string
+		developers bool
+		days       int
+		limit      int
+		halfLife   time.Duration
+		output     string
+		qf         queryFlags
+	)
+	fs := a.newFlagSet("stats")
+	fs.StringVar(&dbPath, "store", "", "database file written by -store of the projects or developers command (required)")
+	fs.BoolVar(&developers, "developers", false, "compute the metrics of developers instead of projects")
+	fs.IntVar(&days, "days", 30, "only use snapshots of the last days; 0 uses all snapshots")
+	fs.IntVar(&limit, "limit", 25, "maximum number of entries to print; 0 prints all")
+	fs.DurationVar(&halfLife, "half-life", analytics.DefaultHalfLife, "age after which a day on trending contributes half to the momentum")
+	fs.StringVar(&output, "format", "text", "output format: text or json")
+	qf.register(fs, false)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if len(dbPath) == 0 {
+		fmt.Fprintln(fs.Output(), "missing -store")
+		fs.Usage()
+		return errUsage
+	}
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid format %q, expected text or json", output)
+	}
+	q, err := qf.query(a.stderr)
+	if err != nil {
+		return err
+	}
+
+	kind := store.KindProjects
+	if developers {
+		kind = store.KindDevelopers
+	}
+	f := store.Filter{
+		Kind:           kind,
+		Since:          q.Since,
+		Language:       q.Language,
+		SpokenLanguage: q.SpokenLanguage,
+	}
+	if days > 0 {
+		f.From = time.Now().AddDate(0, 0, -days)
+	}
+
+	db, err := store.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	snapshots, err := db.Snapshots(f)
+	if err != nil {
+		return err
+	}
+
+	var result any
+	if developers {
+		result = limitStats(analytics.Developers(snapshots), limit)
+	} else {
+		result = limitStats(analytics.Projects(snapshots, analytics.Options{HalfLife: halfLife}), limit)
+	}
+
+	if output == "json" {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	switch stats := result.(type) {
+	case []analytics.ProjectStats:
+		return writeProjectStats(a.stdout, stats)
+	case []analytics.DeveloperStats:
+		return writeDeveloperStats(a.stdout, stats)
+	}
+	return nil
+}
+
+// limitStats returns at most limit entries of stats. A limit of 0 returns all entries.
+func limitStats[T any](stats []T, limit int) []T {
+	if limit > 0 && len(stats) > limit {
+		return stats[:limit]
+	}
+	return stats
+}
+
+// writeProjectStats writes stats as aligned table into w.
+func writeProjectStats(w io.Writer, stats []analytics.ProjectStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANK\tNAME\tDAYS\tBEST RANK\tSTARS\tSTARS/DAY\tMOMENTUM")
+	for i, s := range stats {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\t%.1f\t%.1f\n", i+1, s.Project.Name, s.DaysOnTrending, s.BestRank, s.TotalStars, s.Velocity, s.Momentum)
+	}
+	return tw.Flush()
+}
+
+// writeDeveloperStats writes stats as aligned table into w.
+func writeDeveloperStats(w io.Writer, stats []analytics.DeveloperStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANK\tNAME\tDAYS\tAPPEARANCES\tBEST RANK\tLANGUAGES")
+	for i, s := range stats {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\t%s\n", i+1, s.Developer.DisplayName, s.DaysOnTrending, s.Appearances, s.BestRank, strings.Join(s.Languages, ","))
+	}
+	return tw.Flush()
+}
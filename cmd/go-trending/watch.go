@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/notify"
+	"github.com/andygrunwald/go-trending/watch"
+	"github.com/andygrunwald/go-trending/watchlist"
+)
+
+// webhookDrainTimeout is the maximum time to deliver the queued webhook events after watching stopped.
+// Events that are still undelivered afterwards end up in the dead-letter file.
+const webhookDrainTimeout = 30 * time.Second
+
+// envWebhookSecret is the environment variable with the default of -webhook-secret.
+// It keeps the secret out of the process list.
+const envWebhookSecret = "GO_TRENDING_WEBHOOK_SECRET"
+
+// runWatch implements the "watch" command.
+//
+// It polls the trending pages until the process is interrupted and prints every change as one JSON object per line.
+// With -webhook, every change is POSTed to the webhooks as well.
+func runWatch(a *app, args []string) error {
+	var (
+		cf             clientFlags
+		since          string
+		languages      string
+		spokenLanguage string
+		developers     bool
+		interval       time.Duration
+		jitter         = 0.1
+		starsThreshold int
+		duration       time.Duration
+		webhooks       []string
+		webhookSecret  string
+		deadLetterFile string
+		watchlistFile  string
+	)
+	fs := a.newFlagSet("watch")
+	cf.register(fs)
+	fs.StringVar(&since, "since", trending.TimeToday, "period of time: daily, weekly or monthly")
+	fs.StringVar(&languages, "language", "", "comma separated list of programing languages to watch, e.g. go,rust; empty watches all languages")
+	fs.StringVar(&spokenLanguage, "spoken-language", "", "spoken language code of the projects, e.g. en")
+	fs.BoolVar(&developers, "developers", false, "watch trending developers in addition to projects")
+	fs.DurationVar(&interval, "interval", watch.DefaultInterval, "time between two polls")
+	fs.Func("jitter", "randomize the interval by up to this fraction from 0 to 0.9, e.g. 0.1 for ±10% (default 0.1)", func(s string) error {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || v < 0 || v > watch.MaxJitter {
+			return fmt.Errorf("invalid jitter %q, expected a fraction from 0 to %v", s, watch.MaxJitter)
+		}
+		jitter = v
+		return nil
+	})
+	fs.IntVar(&starsThreshold, "stars-threshold", watch.DefaultStarsThreshold, "minimum increase of stars between two polls to report a stars_jumped event; negative disables it")
+	fs.DurationVar(&duration, "duration", 0, "stop after this duration; 0 watches until interrupted")
+	fs.Func("webhook", "URL to POST every change to; can be repeated", func(s string) error {
+		webhooks = append(webhooks, s)
+		return nil
+	})
+	fs.StringVar(&webhookSecret, "webhook-secret", os.Getenv(envWebhookSecret), "secret to sign the webhook payloads with HMAC-SHA256 (default $"+envWebhookSecret+")")
+	fs.StringVar(&deadLetterFile, "dead-letter", "", "file to append webhook deliveries to that failed after all retries")
+	fs.StringVar(&watchlistFile, "watchlist", "", "YAML file with repositories, owners, keywords and developers to report as watchlist_matched events")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	qf := queryFlags{since: since, spokenLanguage: spokenLanguage}
+	if _, err := qf.query(a.stderr); err != nil {
+		return err
+	}
+	var wl *watchlist.Watchlist
+	if len(watchlistFile) > 0 {
+		var err error
+		if wl, err = watchlist.Load(watchlistFile); err != nil {
+			return err
+		}
+	}
+	targets, err := watchTargets(since, languages, spokenLanguage, developers, a.stderr)
+	if err != nil {
+		return err
+	}
+	trend, err := cf.newTrending()
+	if err != nil {
+		return err
+	}
+
+	// Webhook errors are reported from the goroutine of the queue
+	var stderrMu sync.Mutex
+	warn := func(format string, args ...any) {
+		stderrMu.Lock()
+		defer stderrMu.Unlock()
+		fmt.Fprintf(a.stderr, "go-trending watch: "+format+"\n", args...)
+	}
+
+	w := watch.New(trend, targets, watch.Options{
+		Interval:       interval,
+		Jitter:         jitter,
+		StarsThreshold: starsThreshold,
+		Watchlist:      wl,
+		ErrorHandler: func(t watch.Target, err error) {
+			warn("fetching %s %+v failed: %v", t.Kind(), t.Query, err)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	defer cancel()
+	if duration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	notifyEvent := func(watch.Event) {}
+	if len(webhooks) > 0 {
+		var hooks []notify.Webhook
+		for _, u := range webhooks {
+			hooks = append(hooks, notify.Webhook{URL: u, Secret: webhookSecret})
+		}
+		// Deliveries run in the background, so that a dead webhook doesn't stall polling
+		queue := notify.New(hooks, notify.Options{
+			DeadLetterFile: deadLetterFile,
+			ErrorHandler: func(err error) {
+				warn("%v", err)
+			},
+		}).NewQueue(notify.DefaultQueueSize)
+		notifyEvent = queue.Handler()
+		defer func() {
+			// Deliver the queued events, but don't wait forever for dead webhooks
+			drainCtx, cancel := context.WithTimeout(context.Background(), webhookDrainTimeout)
+			defer cancel()
+			if err := queue.Close(drainCtx); err != nil {
+				warn("undelivered webhook events after %v: %v", webhookDrainTimeout, err)
+			}
+		}()
+	}
+
+	enc := json.NewEncoder(a.stdout)
+	var encodeErr error
+	err = w.Run(ctx, func(e watch.Event) {
+		if encodeErr = enc.Encode(e); encodeErr != nil {
+			// Stdout is gone, e.g. a closed pipe
+			cancel()
+			return
+		}
+		notifyEvent(e)
+	})
+	if encodeErr != nil {
+		return encodeErr
+	}
+
+	// Being stopped is the regular way to end watching
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	return err
+}
+
+// watchTargets returns a target for the projects of every language of the comma separated list languages.
+// If developers is true, a target for the developers of every language is added as well.
+// Warnings about unknown languages are written to stderr.
+func watchTargets(since, languages, spokenLanguage string, developers bool, stderr io.Writer) ([]watch.Target, error) {
+	var targets []watch.Target
+	for _, name := range strings.Split(languages, ",") {
+		language, err := resolveLanguage(name, stderr)
+		if err != nil {
+			return nil, err
+		}
+		q := trending.Query{
+			Since:          since,
+			Language:       language,
+			SpokenLanguage: spokenLanguage,
+		}
+		targets = append(targets, watch.Target{Query: q})
+		if developers {
+			targets = append(targets, watch.Target{Query: trending.Query{Since: q.Since, Language: q.Language}, Developers: true})
+		}
+	}
+	return targets, nil
+}
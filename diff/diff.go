@@ -0,0 +1,229 @@
+// Package diff compares two trending results of the same query.
+//
+// It reports which projects or developers are new on the trending page,
+// which ones fell off and how ranks and stars changed:
+//
+//	yesterday, _ := trend.GetProjects(trending.TimeToday, "go")
+//	...
+//	today, _ := trend.GetProjects(trending.TimeToday, "go")
+//
+//	d := diff.Projects(yesterday, today)
+//	for _, c := range d.Entered {
+//		fmt.Printf("New on trending: %s (rank %d)\n", c.Project.Name, c.NewRank)
+//	}
+//
+// Projects are identified by their name, developers by their ID (or login if the ID is unknown).
+// Names and logins are compared regardless of case, so "Golang/Go" and "golang/go" are the same project.
+package diff
+
+import (
+	"strings"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// ProjectChange describes how a single project changed between two results.
+// Ranks are 1-based. A rank of 0 means the project is not part of the result.
+type ProjectChange struct {
+	// Project is the project as of the new result or, if it left the trending page, as of the old result.
+	Project trending.Project `json:"project"`
+
+	// OldRank is the rank in the old result.
+	OldRank int `json:"old_rank"`
+
+	// NewRank is the rank in the new result.
+	NewRank int `json:"new_rank"`
+
+	// OldStars are the stars in the old result.
+	OldStars int `json:"old_stars"`
+
+	// NewStars are the stars in the new result.
+	NewStars int `json:"new_stars"`
+}
+
+// RankDelta is the number of ranks the project moved up. Negative values mean the project moved down.
+// It is 0 for projects that entered or left the trending page.
+func (c ProjectChange) RankDelta() int {
+	if c.OldRank == 0 || c.NewRank == 0 {
+		return 0
+	}
+	return c.OldRank - c.NewRank
+}
+
+// StarsDelta is the difference of the stars between the new and the old result.
+func (c ProjectChange) StarsDelta() int {
+	return c.NewStars - c.OldStars
+}
+
+// ProjectsDiff is the difference between two project results.
+// Every project of both results is part of exactly one list.
+// All lists are ordered by the rank in the new result, or the old one for Left.
+type ProjectsDiff struct {
+	// Entered are projects that are new on the trending page.
+	Entered []ProjectChange `json:"entered"`
+
+	// Left are projects that fell off the trending page.
+	Left []ProjectChange `json:"left"`
+
+	// MovedUp are projects with a better rank than before.
+	MovedUp []ProjectChange `json:"moved_up"`
+
+	// MovedDown are projects with a worse rank than before.
+	MovedDown []ProjectChange `json:"moved_down"`
+
+	// Unchanged are projects that kept their rank. Their stars might have changed.
+	Unchanged []ProjectChange `json:"unchanged"`
+}
+
+// Changed reports whether projects entered, left or moved.
+func (d ProjectsDiff) Changed() bool {
+	return len(d.Entered) > 0 || len(d.Left) > 0 || len(d.MovedUp) > 0 || len(d.MovedDown) > 0
+}
+
+// Projects compares the project results old and new.
+func Projects(old, new []trending.Project) ProjectsDiff {
+	var d ProjectsDiff
+
+	oldRanks := make(map[string]int, len(old))
+	for i, p := range old {
+		oldRanks[projectKey(p)] = i + 1
+	}
+
+	newRanks := make(map[string]int, len(new))
+	for i, p := range new {
+		key := projectKey(p)
+		newRanks[key] = i + 1
+
+		c := ProjectChange{
+			Project:  p,
+			NewRank:  i + 1,
+			NewStars: p.Stars,
+		}
+		if oldRank, ok := oldRanks[key]; ok {
+			c.OldRank = oldRank
+			c.OldStars = old[oldRank-1].Stars
+		}
+
+		switch {
+		case c.OldRank == 0:
+			d.Entered = append(d.Entered, c)
+		case c.RankDelta() > 0:
+			d.MovedUp = append(d.MovedUp, c)
+		case c.RankDelta() < 0:
+			d.MovedDown = append(d.MovedDown, c)
+		default:
+			d.Unchanged = append(d.Unchanged, c)
+		}
+	}
+
+	for i, p := range old {
+		if _, ok := newRanks[projectKey(p)]; ok {
+			continue
+		}
+		d.Left = append(d.Left, ProjectChange{
+			Project:  p,
+			OldRank:  i + 1,
+			OldStars: p.Stars,
+		})
+	}
+
+	return d
+}
+
+// DeveloperChange describes how a single developer changed between two results.
+// Ranks are 1-based. A rank of 0 means the developer is not part of the result.
+type DeveloperChange struct {
+	// Developer is the developer as of the new result or, if it left the trending page, as of the old result.
+	Developer trending.Developer `json:"developer"`
+
+	// OldRank is the rank in the old result.
+	OldRank int `json:"old_rank"`
+
+	// NewRank is the rank in the new result.
+	NewRank int `json:"new_rank"`
+}
+
+// RankDelta is the number of ranks the developer moved up. Negative values mean the developer moved down.
+// It is 0 for developers that entered or left the trending page.
+func (c DeveloperChange) RankDelta() int {
+	if c.OldRank == 0 || c.NewRank == 0 {
+		return 0
+	}
+	return c.OldRank - c.NewRank
+}
+
+// DevelopersDiff is the difference between two developer results.
+// Every developer of both results is part of exactly one list.
+// All lists are ordered by the rank in the new result, or the old one for Left.
+type DevelopersDiff struct {
+	// Entered are developers that are new on the trending page.
+	Entered []DeveloperChange `json:"entered"`
+
+	// Left are developers that fell off the trending page.
+	Left []DeveloperChange `json:"left"`
+
+	// MovedUp are developers with a better rank than before.
+	MovedUp []DeveloperChange `json:"moved_up"`
+
+	// MovedDown are developers with a worse rank than before.
+	MovedDown []DeveloperChange `json:"moved_down"`
+
+	// Unchanged are developers that kept their rank.
+	Unchanged []DeveloperChange `json:"unchanged"`
+}
+
+// Changed reports whether developers entered, left or moved.
+func (d DevelopersDiff) Changed() bool {
+	return len(d.Entered) > 0 || len(d.Left) > 0 || len(d.MovedUp) > 0 || len(d.MovedDown) > 0
+}
+
+// Developers compares the developer results old and new.
+func Developers(old, new []trending.Developer) DevelopersDiff {
+	var d DevelopersDiff
+
+	oldRanks := make(map[string]int, len(old))
+	for i, dev := range old {
+		oldRanks[shared.DeveloperKey(dev)] = i + 1
+	}
+
+	newRanks := make(map[string]int, len(new))
+	for i, dev := range new {
+		key := shared.DeveloperKey(dev)
+		newRanks[key] = i + 1
+
+		c := DeveloperChange{
+			Developer: dev,
+			NewRank:   i + 1,
+			OldRank:   oldRanks[key],
+		}
+
+		switch {
+		case c.OldRank == 0:
+			d.Entered = append(d.Entered, c)
+		case c.RankDelta() > 0:
+			d.MovedUp = append(d.MovedUp, c)
+		case c.RankDelta() < 0:
+			d.MovedDown = append(d.MovedDown, c)
+		default:
+			d.Unchanged = append(d.Unchanged, c)
+		}
+	}
+
+	for i, dev := range old {
+		if _, ok := newRanks[shared.DeveloperKey(dev)]; ok {
+			continue
+		}
+		d.Left = append(d.Left, DeveloperChange{
+			Developer: dev,
+			OldRank:   i + 1,
+		})
+	}
+
+	return d
+}
+
+// projectKey returns the identifier of p used for comparison.
+func projectKey(p trending.Project) string {
+	return strings.ToLower(p.Name)
+}
@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// project returns a trending.Project with name and stars.
+func project(name string, stars int) trending.Project {
+	return trending.Project{Name: name, Stars: stars}
+}
+
+func TestProjects(t *testing.T) {
+	old := []trending.Project{
+		project("a/one", 100),
+		project("b/two", 90),
+		project("c/three", 80),
+		project("d/four", 70),
+	}
+	new := []trending.Project{
+		project("B/Two", 150),
+		project("a/one", 120),
+		project("e/five", 60),
+		project("c/three", 85),
+	}
+
+	got := Projects(old, new)
+	want := ProjectsDiff{
+		Entered: []ProjectChange{{Project: new[2], NewRank: 3, NewStars: 60}},
+		Left:    []ProjectChange{{Project: old[3], OldRank: 4, OldStars: 70}},
+		MovedUp: []ProjectChange{{Project: new[0], OldRank: 2, NewRank: 1, OldStars: 90, NewStars: 150}},
+		MovedDown: []ProjectChange{
+			{Project: new[1], OldRank: 1, NewRank: 2, OldStars: 100, NewStars: 120},
+			{Project: new[3], OldRank: 3, NewRank: 4, OldStars: 80, NewStars: 85},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Projects returned %+v, want %+v", got, want)
+	}
+	if !got.Changed() {
+		t.Error("Changed returned false, want true")
+	}
+
+	if delta := got.MovedUp[0].RankDelta(); delta != 1 {
+		t.Errorf("RankDelta returned %d, want 1", delta)
+	}
+	if delta := got.MovedUp[0].StarsDelta(); delta != 60 {
+		t.Errorf("StarsDelta returned %d, want 60", delta)
+	}
+	if delta := got.Entered[0].RankDelta(); delta != 0 {
+		t.Errorf("RankDelta of an entered project returned %d, want 0", delta)
+	}
+}
+
+func TestProjects_Unchanged(t *testing.T) {
+	projects := []trending.Project{project("a/one", 100), project("b/two", 90)}
+	newProjects := []trending.Project{project("a/one", 110), project("b/two", 95)}
+
+	got := Projects(projects, newProjects)
+	if got.Changed() {
+		t.Errorf("Changed returned true for %+v, want false", got)
+	}
+	if len(got.Unchanged) != 2 || got.Unchanged[0].StarsDelta() != 10 {
+		t.Errorf("Projects returned %+v unchanged projects, want 2 with a star delta of 10 for the first", got.Unchanged)
+	}
+}
+
+func TestDevelopers(t *testing.T) {
+	old := []trending.Developer{
+		{ID: 1, DisplayName: "one"},
+		{ID: 2, DisplayName: "two"},
+		{DisplayName: "NoID"},
+	}
+	new := []trending.Developer{
+		{ID: 2, DisplayName: "renamed-two"},
+		{DisplayName: "noid"},
+		{ID: 4, DisplayName: "four"},
+	}
+
+	got := Developers(old, new)
+	want := DevelopersDiff{
+		Entered:   []DeveloperChange{{Developer: new[2], NewRank: 3}},
+		Left:      []DeveloperChange{{Developer: old[0], OldRank: 1}},
+		MovedUp:   []DeveloperChange{{Developer: new[0], OldRank: 2, NewRank: 1}, {Developer: new[1], OldRank: 3, NewRank: 2}},
+		MovedDown: nil,
+		Unchanged: nil,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Developers returned %+v, want %+v", got, want)
+	}
+}
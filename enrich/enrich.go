@@ -0,0 +1,303 @@
+// Package enrich adds data of the GitHub REST API to trending projects and developers,
+// like the license and the topics of a repository or the company and the followers of a developer.
+//
+// The trending page only lists names, descriptions and stars. An Enricher looks up every project
+// at /repos/{owner}/{repo} and stores the result in trending.Project.Enrichment:
+//
+//	e := enrich.New(os.Getenv("GITHUB_TOKEN"))
+//	projects, err := trend.GetProjects(trending.TimeToday, "go")
+//	...
+//	if err := e.Projects(ctx, projects); err != nil {
+//		...
+//	}
+//	for _, p := range projects {
+//		if p.Enrichment != nil {
+//			fmt.Println(p.Name, p.Enrichment.License, p.Enrichment.Topics)
+//		}
+//	}
+//
+// Developers are looked up at /users/{login} and stored in trending.Developer.Enrichment, see Enricher.Developers.
+//
+// For many projects, GraphQL looks them up in batches at the GraphQL API with one query per 50 projects
+// instead of one request per project.
+//
+// Without a token, the API allows only 60 requests per hour.
+// For GitHub Enterprise, set BaseURL to the API address like https://github.example.com/api/v3/.
+package enrich
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// Defaults of an Enricher.
+const (
+	// DefaultBaseURL is the address of the public GitHub REST API.
+	DefaultBaseURL = "https://api.github.com/"
+	// DefaultConcurrency is the maximum number of parallel requests.
+	DefaultConcurrency = 4
+)
+
+// Enricher looks up trending projects and developers at the GitHub REST API.
+// It is safe for concurrent use, as long as the fields are not changed.
+type Enricher struct {
+	// BaseURL is the address of the REST API. Defaults to DefaultBaseURL.
+	// BaseURL should always be specified with a trailing slash.
+	BaseURL *url.URL
+
+	// Client to use for requests.
+	Client *http.Client
+
+	// Token authenticates the requests, like a personal access token. If empty, requests are anonymous.
+	Token string
+
+	// Concurrency is the maximum number of parallel requests of Projects and Developers.
+	// Defaults to DefaultConcurrency if zero or negative.
+	Concurrency int
+
+	// Cache stores API responses to avoid requesting the same repository or user again.
+	// Defaults to nil (no caching). See trending.NewMemoryCache.
+	Cache trending.Cache
+}
+
+// New returns an Enricher for the public GitHub API that authenticates with token.
+func New(token string) *Enricher {
+	baseURL, _ := url.Parse(DefaultBaseURL)
+	return &Enricher{
+		BaseURL: baseURL,
+		Client:  http.DefaultClient,
+		Token:   token,
+	}
+}
+
+// repository is the part of the response of /repos/{owner}/{repo} that is used.
+type repository struct {
+	License *struct {
+		SPDXID string `json:"spdx_id"`
+	} `json:"license"`
+	Topics          []string  `json:"topics"`
+	Homepage        string    `json:"homepage"`
+	Archived        bool      `json:"archived"`
+	OpenIssuesCount int       `json:"open_issues_count"`
+	StargazersCount int       `json:"stargazers_count"`
+	ForksCount      int       `json:"forks_count"`
+	CreatedAt       time.Time `json:"created_at"`
+	PushedAt        time.Time `json:"pushed_at"`
+}
+
+// Repository returns the enrichment of the repository owner/name.
+func (e *Enricher) Repository(ctx context.Context, owner, name string) (*trending.Enrichment, error) {
+	var r repository
+	if err := e.get(ctx, "repos/"+url.PathEscape(owner)+"/"+url.PathEscape(name), &r); err != nil {
+		return nil, err
+	}
+
+	enrichment := &trending.Enrichment{
+		Topics:     r.Topics,
+		Homepage:   r.Homepage,
+		Archived:   r.Archived,
+		OpenIssues: r.OpenIssuesCount,
+		TotalStars: r.StargazersCount,
+		Forks:      r.ForksCount,
+		CreatedAt:  r.CreatedAt,
+		PushedAt:   r.PushedAt,
+	}
+	if r.License != nil {
+		enrichment.License = r.License.SPDXID
+	}
+	return enrichment, nil
+}
+
+// Projects sets the Enrichment of every project, with up to Concurrency requests in parallel.
+// Projects that can't be looked up keep their Enrichment.
+// All projects are tried, the returned error joins the errors of all failed lookups.
+func (e *Enricher) Projects(ctx context.Context, projects []trending.Project) error {
+	return forEach(ctx, len(projects), e.Concurrency, func(i int) error {
+		p := &projects[i]
+		enrichment, err := e.Repository(ctx, p.Owner, p.RepositoryName)
+		if err != nil {
+			return fmt.Errorf("enrich: %s: %w", p.Name, err)
+		}
+		p.Enrichment = enrichment
+		return nil
+	})
+}
+
+// user is the part of the response of /users/{login} that is used.
+type user struct {
+	Company     string    `json:"company"`
+	Location    string    `json:"location"`
+	Bio         string    `json:"bio"`
+	Blog        string    `json:"blog"`
+	Followers   int       `json:"followers"`
+	PublicRepos int       `json:"public_repos"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// User returns the enrichment of the user or organisation login.
+func (e *Enricher) User(ctx context.Context, login string) (*trending.DeveloperEnrichment, error) {
+	var u user
+	if err := e.get(ctx, "users/"+url.PathEscape(login), &u); err != nil {
+		return nil, err
+	}
+
+	return &trending.DeveloperEnrichment{
+		Company:     u.Company,
+		Location:    u.Location,
+		Bio:         u.Bio,
+		Blog:        u.Blog,
+		Followers:   u.Followers,
+		PublicRepos: u.PublicRepos,
+		CreatedAt:   u.CreatedAt,
+	}, nil
+}
+
+// Developers sets the Enrichment of every developer, with up to Concurrency requests in parallel.
+// Developers are looked up by the login of their URL like "torvalds" of https://github.com/torvalds,
+// or by their DisplayName if the URL is unknown.
+// Developers that can't be looked up keep their Enrichment.
+// All developers are tried, the returned error joins the errors of all failed lookups.
+func (e *Enricher) Developers(ctx context.Context, developers []trending.Developer) error {
+	return forEach(ctx, len(developers), e.Concurrency, func(i int) error {
+		d := &developers[i]
+		enrichment, err := e.User(ctx, login(*d))
+		if err != nil {
+			return fmt.Errorf("enrich: %s: %w", login(*d), err)
+		}
+		d.Enrichment = enrichment
+		return nil
+	})
+}
+
+// login returns the login of d, the last path segment of the URL or the DisplayName.
+func login(d trending.Developer) string {
+	if d.URL != nil {
+		if name := path.Base(strings.TrimSuffix(d.URL.Path, "/")); name != "/" && name != "." {
+			return name
+		}
+	}
+	return d.DisplayName
+}
+
+// get requests ref relative to BaseURL and decodes the JSON response into v.
+// Non-2xx responses are returned as *trending.StatusError.
+func (e *Enricher) get(ctx context.Context, ref string, v any) error {
+	u, err := e.BaseURL.Parse(ref)
+	if err != nil {
+		return err
+	}
+	key := u.String()
+
+	if e.Cache != nil {
+		if entry, ok := e.Cache.Get(key); ok {
+			return json.Unmarshal(entry.Body, v)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return err
+	}
+	setHeaders(req, e.Token)
+
+	res, err := e.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		io.Copy(io.Discard, res.Body)
+		return statusError(key, res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(v); err != nil {
+		return fmt.Errorf("decoding %s: %w", key, err)
+	}
+
+	if e.Cache != nil {
+		e.Cache.Set(key, trending.CacheEntry{Body: body, FetchedAt: time.Now()})
+	}
+	return nil
+}
+
+// setHeaders sets the headers of every API request, including the authorization if token is set.
+func setHeaders(req *http.Request, token string) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "go-trending")
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// statusError returns the error of the unexpected response res of a request to u.
+// If the rate limit is exhausted, RetryAfter is derived from the reset time of the limit.
+func statusError(u string, res *http.Response) error {
+	err := &trending.StatusError{
+		URL:        u,
+		StatusCode: res.StatusCode,
+		RetryAfter: res.Header.Get("Retry-After"),
+	}
+	if len(err.RetryAfter) == 0 && res.Header.Get("X-RateLimit-Remaining") == "0" {
+		var reset int64
+		if _, scanErr := fmt.Sscan(res.Header.Get("X-RateLimit-Reset"), &reset); scanErr == nil {
+			err.RetryAfter = fmt.Sprint(max(0, reset-time.Now().Unix()))
+		}
+	}
+	return err
+}
+
+// forEach calls fn for 0 to n-1 with up to concurrency calls in parallel.
+// No new calls are started after ctx is canceled. The returned error joins all errors of fn.
+func forEach(ctx context.Context, n, concurrency int, fn func(i int) error) error {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, concurrency)
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		select {
+		case <-ctx.Done():
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(i); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,252 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// repositoryJSON is a shortened response of /repos/{owner}/{repo}.
+const repositoryJSON = `{
+	"full_name": "andygrunwald/go-trending",
+	"license": {"key": "mit", "spdx_id": "MIT"},
+	"topics": ["github", "trending"],
+	"homepage": "https://pkg.go.dev/github.com/andygrunwald/go-trending",
+	"archived": true,
+	"open_issues_count": 3,
+	"stargazers_count": 140,
+	"forks_count": 20,
+	"created_at": "2015-03-10T18:55:10Z",
+	"pushed_at": "2023-05-01T07:00:00Z"
+}`
+
+// newTestEnricher returns an Enricher that talks to a test server answering with handler.
+func newTestEnricher(t *testing.T, handler http.Handler) *Enricher {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	e := New("s3cr3t")
+	e.BaseURL, _ = url.Parse(server.URL + "/api/v3/")
+	return e
+}
+
+func TestEnricher_Repository(t *testing.T) {
+	var header http.Header
+	e := newTestEnricher(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/repos/andygrunwald/go-trending" {
+			http.NotFound(w, r)
+			return
+		}
+		header = r.Header
+		w.Write([]byte(repositoryJSON))
+	}))
+
+	got, err := e.Repository(context.Background(), "andygrunwald", "go-trending")
+	if err != nil {
+		t.Fatalf("Repository returned error: %v", err)
+	}
+
+	want := &trending.Enrichment{
+		License:    "MIT",
+		Topics:     []string{"github", "trending"},
+		Homepage:   "https://pkg.go.dev/github.com/andygrunwald/go-trending",
+		Archived:   true,
+		OpenIssues: 3,
+		TotalStars: 140,
+		Forks:      20,
+		CreatedAt:  time.Date(2015, 3, 10, 18, 55, 10, 0, time.UTC),
+		PushedAt:   time.Date(2023, 5, 1, 7, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Repository returned %+v, want %+v", got, want)
+	}
+
+	if got := header.Get("Authorization"); got != "Bearer s3cr3t" {
+		t.Errorf("Repository sent Authorization %q, want %q", got, "Bearer s3cr3t")
+	}
+	if got := header.Get("Accept"); got != "application/vnd.github+json" {
+		t.Errorf("Repository sent Accept %q, want %q", got, "application/vnd.github+json")
+	}
+}
+
+func TestEnricher_RepositoryRateLimited(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Unix()
+	e := newTestEnricher(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+
+	_, err := e.Repository(context.Background(), "a", "b")
+	var statusErr *trending.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("Repository returned error %v, want a *trending.StatusError with 403", err)
+	}
+	if statusErr.RetryAfter == "" || statusErr.RetryAfter == "0" {
+		t.Errorf("Repository returned RetryAfter %q, want the seconds until the reset", statusErr.RetryAfter)
+	}
+}
+
+func TestEnricher_Projects(t *testing.T) {
+	var (
+		requests  atomic.Int32
+		running   atomic.Int32
+		mu        sync.Mutex
+		maxActive int32
+	)
+	e := newTestEnricher(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		active := running.Add(1)
+		defer running.Add(-1)
+		mu.Lock()
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"license": null, "topics": ["` + strings.TrimPrefix(r.URL.Path, "/api/v3/repos/") + `"]}`))
+	}))
+	e.Concurrency = 2
+	e.Cache = trending.NewMemoryCache(time.Minute)
+
+	var projects []trending.Project
+	for _, name := range []string{"a/one", "b/two", "c/three", "d/missing", "e/five"} {
+		owner, repository, _ := strings.Cut(name, "/")
+		projects = append(projects, trending.Project{Name: name, Owner: owner, RepositoryName: repository})
+	}
+
+	err := e.Projects(context.Background(), projects)
+	var statusErr *trending.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound || !strings.Contains(err.Error(), "d/missing") {
+		t.Errorf("Projects returned error %v, want a 404 of d/missing", err)
+	}
+
+	for _, p := range projects {
+		switch {
+		case p.Name == "d/missing" && p.Enrichment != nil:
+			t.Errorf("Projects enriched %s with %+v, want nil", p.Name, p.Enrichment)
+		case p.Name != "d/missing" && (p.Enrichment == nil || !reflect.DeepEqual(p.Enrichment.Topics, []string{p.Name})):
+			t.Errorf("Projects enriched %s with %+v, want the topic %s", p.Name, p.Enrichment, p.Name)
+		}
+	}
+	if maxActive > 2 {
+		t.Errorf("Projects sent %d parallel requests, want at most 2", maxActive)
+	}
+
+	// The second run is served from the cache, except the missing project
+	e.Projects(context.Background(), projects)
+	if got := requests.Load(); got != 6 {
+		t.Errorf("Projects sent %d requests in two runs, want 6", got)
+	}
+}
+
+func TestEnricher_ProjectsCanceled(t *testing.T) {
+	e := newTestEnricher(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Projects requested %s after the context was canceled", r.URL)
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	projects := []trending.Project{{Name: "a/b", Owner: "a", RepositoryName: "b"}}
+	if err := e.Projects(ctx, projects); !errors.Is(err, context.Canceled) {
+		t.Errorf("Projects returned error %v, want context.Canceled", err)
+	}
+}
+
+// userJSON is a shortened response of /users/{login}.
+const userJSON = `{
+	"login": "andygrunwald",
+	"type": "User",
+	"company": "@trivago",
+	"location": "Düsseldorf, Germany",
+	"bio": "Engineering Manager",
+	"blog": "https://andygrunwald.com/",
+	"followers": 900,
+	"public_repos": 120,
+	"created_at": "2010-06-13T09:41:29Z"
+}`
+
+func TestEnricher_User(t *testing.T) {
+	e := newTestEnricher(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/users/andygrunwald" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(userJSON))
+	}))
+
+	got, err := e.User(context.Background(), "andygrunwald")
+	if err != nil {
+		t.Fatalf("User returned error: %v", err)
+	}
+
+	want := &trending.DeveloperEnrichment{
+		Company:     "@trivago",
+		Location:    "Düsseldorf, Germany",
+		Bio:         "Engineering Manager",
+		Blog:        "https://andygrunwald.com/",
+		Followers:   900,
+		PublicRepos: 120,
+		CreatedAt:   time.Date(2010, 6, 13, 9, 41, 29, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("User returned %+v, want %+v", got, want)
+	}
+}
+
+func TestEnricher_Developers(t *testing.T) {
+	var requests atomic.Int32
+	e := newTestEnricher(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		login := strings.TrimPrefix(r.URL.Path, "/api/v3/users/")
+		if login == "ghost" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"location": "` + login + `", "followers": 1}`))
+	}))
+	e.Cache = trending.NewMemoryCache(time.Minute)
+
+	bob, _ := url.Parse("https://github.com/bob-the-builder")
+	developers := []trending.Developer{{DisplayName: "alice"}, {DisplayName: "ghost"}, {DisplayName: "bob", URL: bob}}
+	err := e.Developers(context.Background(), developers)
+	if err == nil || !strings.Contains(err.Error(), "ghost") {
+		t.Errorf("Developers returned error %v, want a failed lookup of ghost", err)
+	}
+
+	for _, d := range developers {
+		switch {
+		case d.DisplayName == "ghost" && d.Enrichment != nil:
+			t.Errorf("Developers enriched %s with %+v, want nil", d.DisplayName, d.Enrichment)
+		case d.DisplayName != "ghost" && (d.Enrichment == nil || d.Enrichment.Location != login(d)):
+			t.Errorf("Developers enriched %s with %+v, want the location %s", d.DisplayName, d.Enrichment, login(d))
+		}
+	}
+
+	if got := developers[2].Enrichment; got == nil || got.Location != "bob-the-builder" {
+		t.Errorf("Developers enriched bob with %+v, want the login of the URL", got)
+	}
+
+	// Users are cached like repositories
+	e.Developers(context.Background(), developers[:1])
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Developers sent %d requests, want 3", got)
+	}
+}
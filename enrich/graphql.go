@@ -0,0 +1,393 @@
+package enrich
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// Defaults of a GraphQL enricher.
+const (
+	// DefaultGraphQLURL is the address of the public GitHub GraphQL API.
+	DefaultGraphQLURL = "https://api.github.com/graphql"
+	// DefaultBatchSize is the maximum number of repositories per query.
+	DefaultBatchSize = 50
+)
+
+// GraphQL looks up trending projects in batches at the GitHub GraphQL API.
+// Every query fetches up to BatchSize repositories at once, which needs far less requests
+// and rate limit points than one REST request per project. The result is the same trending.Enrichment.
+//
+// The GraphQL API always requires a token.
+// For GitHub Enterprise, set Endpoint to the API address like https://github.example.com/api/graphql.
+//
+// A GraphQL enricher is safe for concurrent use, as long as the fields are not changed.
+type GraphQL struct {
+	// Endpoint is the address of the GraphQL API. Defaults to DefaultGraphQLURL.
+	Endpoint *url.URL
+
+	// Client to use for requests.
+	Client *http.Client
+
+	// Token authenticates the requests, like a personal access token.
+	Token string
+
+	// BatchSize is the maximum number of repositories per query.
+	// Defaults to DefaultBatchSize if zero or negative.
+	BatchSize int
+
+	// Cache stores the data of every repository to avoid requesting it again.
+	// Defaults to nil (no caching). See trending.NewMemoryCache.
+	Cache trending.Cache
+
+	mu        sync.Mutex
+	rateLimit RateLimit
+}
+
+// NewGraphQL returns a GraphQL enricher for the public GitHub API that authenticates with token.
+func NewGraphQL(token string) *GraphQL {
+	endpoint, _ := url.Parse(DefaultGraphQLURL)
+	return &GraphQL{
+		Endpoint: endpoint,
+		Client:   http.DefaultClient,
+		Token:    token,
+	}
+}
+
+// RateLimit is the state of the rate limit of the GraphQL API after a query.
+type RateLimit struct {
+	// Cost is the number of points the last query cost.
+	Cost int `json:"cost"`
+
+	// Remaining is the number of points left in the current window.
+	Remaining int `json:"remaining"`
+
+	// ResetAt is the time the window resets.
+	ResetAt time.Time `json:"resetAt"`
+}
+
+// RateLimitError is returned if the rate limit of the GraphQL API is too low for the next query.
+type RateLimitError struct {
+	RateLimit
+}
+
+// Error returns a message like "enrich: GraphQL rate limit exceeded, 0 points remaining until 15:04:05".
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("enrich: GraphQL rate limit exceeded, %d points remaining until %s", e.Remaining, e.ResetAt.Local().Format(time.TimeOnly))
+}
+
+// RateLimit returns the rate limit reported by the last query. It is zero before the first query.
+func (g *GraphQL) RateLimit() RateLimit {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.rateLimit
+}
+
+// repositoryFields are the fields of a repository that are queried.
+const repositoryFields = `fragment enrichment on Repository {
+  licenseInfo { spdxId }
+  repositoryTopics(first: 20) { nodes { topic { name } } }
+  homepageUrl
+  isArchived
+  issues(states: OPEN) { totalCount }
+  pullRequests(states: OPEN) { totalCount }
+  stargazerCount
+  forkCount
+  createdAt
+  pushedAt
+}`
+
+// graphQLRepository is a repository of the GraphQL response.
+type graphQLRepository struct {
+	LicenseInfo *struct {
+		SPDXID string `json:"spdxId"`
+	} `json:"licenseInfo"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+	HomepageURL string `json:"homepageUrl"`
+	IsArchived  bool   `json:"isArchived"`
+	Issues      struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"issues"`
+	PullRequests struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"pullRequests"`
+	StargazerCount int       `json:"stargazerCount"`
+	ForkCount      int       `json:"forkCount"`
+	CreatedAt      time.Time `json:"createdAt"`
+	PushedAt       time.Time `json:"pushedAt"`
+}
+
+// enrichment converts r into the enrichment of a project.
+func (r *graphQLRepository) enrichment() *trending.Enrichment {
+	enrichment := &trending.Enrichment{
+		Homepage: r.HomepageURL,
+		Archived: r.IsArchived,
+		// Like the REST API, count open pull requests as issues
+		OpenIssues: r.Issues.TotalCount + r.PullRequests.TotalCount,
+		TotalStars: r.StargazerCount,
+		Forks:      r.ForkCount,
+		CreatedAt:  r.CreatedAt,
+		PushedAt:   r.PushedAt,
+	}
+	if r.LicenseInfo != nil {
+		enrichment.License = r.LicenseInfo.SPDXID
+	}
+	for _, n := range r.RepositoryTopics.Nodes {
+		enrichment.Topics = append(enrichment.Topics, n.Topic.Name)
+	}
+	return enrichment
+}
+
+// graphQLResponse is the response of a query.
+type graphQLResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []graphQLError             `json:"errors"`
+}
+
+// graphQLError is an error of a query, like a repository that doesn't exist.
+type graphQLError struct {
+	Type    string   `json:"type"`
+	Path    []string `json:"path"`
+	Message string   `json:"message"`
+}
+
+// Repositories returns the enrichments of the repositories names like "andygrunwald/go-trending",
+// with one query per BatchSize repositories.
+// Repositories that can't be looked up are missing in the result,
+// the returned error joins the errors of all failed lookups.
+//
+// Before every query, the rate limit of the previous query is checked.
+// If less points remain than the previous query cost, Repositories stops with a *RateLimitError.
+func (g *GraphQL) Repositories(ctx context.Context, names []string) (map[string]*trending.Enrichment, error) {
+	result := make(map[string]*trending.Enrichment, len(names))
+	var errs []error
+
+	var missing []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, _, ok := strings.Cut(name, "/"); !ok {
+			errs = append(errs, fmt.Errorf("enrich: %s: invalid repository name, expected owner/name", name))
+			continue
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if r, ok := g.cached(name); ok {
+			result[name] = r.enrichment()
+			continue
+		}
+		missing = append(missing, name)
+	}
+
+	batchSize := g.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	for len(missing) > 0 {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := g.checkRateLimit(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		batch := missing[:min(batchSize, len(missing))]
+		missing = missing[len(batch):]
+		if err := g.query(ctx, batch, result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// Projects sets the Enrichment of every project, with one query per BatchSize projects.
+// Projects that can't be looked up keep their Enrichment.
+// All projects are tried unless the rate limit is exhausted, see Repositories.
+// The returned error joins the errors of all failed lookups.
+func (g *GraphQL) Projects(ctx context.Context, projects []trending.Project) error {
+	names := make([]string, len(projects))
+	for i, p := range projects {
+		names[i] = p.Owner + "/" + p.RepositoryName
+	}
+
+	enrichments, err := g.Repositories(ctx, names)
+	for i, name := range names {
+		if enrichment, ok := enrichments[name]; ok {
+			projects[i].Enrichment = enrichment
+		}
+	}
+	return err
+}
+
+// checkRateLimit returns a *RateLimitError if the remaining points are less than the cost of the last query.
+func (g *GraphQL) checkRateLimit() error {
+	rl := g.RateLimit()
+	if rl.ResetAt.IsZero() || time.Now().After(rl.ResetAt) {
+		return nil
+	}
+	if rl.Remaining < max(rl.Cost, 1) {
+		return &RateLimitError{RateLimit: rl}
+	}
+	return nil
+}
+
+// query looks up the repositories names in a single query and adds them to result.
+func (g *GraphQL) query(ctx context.Context, names []string, result map[string]*trending.Enrichment) error {
+	// Every repository gets an alias like r0 and its own variables, so that names never end up in the query
+	var query strings.Builder
+	var args []string
+	variables := make(map[string]string, 2*len(names))
+	for i, name := range names {
+		owner, repository, _ := strings.Cut(name, "/")
+		variables[fmt.Sprintf("o%d", i)] = owner
+		variables[fmt.Sprintf("n%d", i)] = repository
+		args = append(args, fmt.Sprintf("$o%d: String!, $n%d: String!", i, i))
+		fmt.Fprintf(&query, "  r%d: repository(owner: $o%d, name: $n%d) { ...enrichment }\n", i, i, i)
+	}
+	body, err := json.Marshal(map[string]any{
+		"query":     "query(" + strings.Join(args, ", ") + ") {\n  rateLimit { cost remaining resetAt }\n" + query.String() + "}\n" + repositoryFields,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := g.post(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, e := range res.Errors {
+		switch {
+		case e.Type == "RATE_LIMITED":
+			return &RateLimitError{RateLimit: g.RateLimit()}
+		case len(e.Path) == 0:
+			errs = append(errs, fmt.Errorf("enrich: GraphQL: %s", e.Message))
+		}
+	}
+	if len(res.Data) == 0 && len(errs) > 0 {
+		// The whole query failed, like a syntax error
+		return errors.Join(errs...)
+	}
+	for i, name := range names {
+		raw := res.Data[fmt.Sprintf("r%d", i)]
+		var r *graphQLRepository
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &r); err != nil {
+				errs = append(errs, fmt.Errorf("enrich: %s: %w", name, err))
+				continue
+			}
+		}
+		if r == nil {
+			errs = append(errs, fmt.Errorf("enrich: %s: %s", name, pathError(res.Errors, fmt.Sprintf("r%d", i))))
+			continue
+		}
+		result[name] = r.enrichment()
+		if g.Cache != nil {
+			g.Cache.Set(cacheKey(name), trending.CacheEntry{Body: raw, FetchedAt: time.Now()})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// pathError returns the message of the error of the alias, or a generic message if there is none.
+func pathError(errs []graphQLError, alias string) string {
+	for _, e := range errs {
+		if len(e.Path) > 0 && e.Path[0] == alias {
+			return e.Message
+		}
+	}
+	return "repository not found"
+}
+
+// post sends the query body and decodes the response.
+// The rate limit is updated from the response, the rate limit headers take precedence over the rateLimit field.
+// Non-2xx responses are returned as *trending.StatusError.
+func (g *GraphQL) post(ctx context.Context, body []byte) (*graphQLResponse, error) {
+	endpoint := g.Endpoint.String()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	setHeaders(req, g.Token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := g.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		io.Copy(io.Discard, res.Body)
+		return nil, statusError(endpoint, res)
+	}
+
+	var r graphQLResponse
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", endpoint, err)
+	}
+
+	var rl RateLimit
+	known := false
+	if raw, ok := r.Data["rateLimit"]; ok && string(raw) != "null" {
+		known = json.Unmarshal(raw, &rl) == nil
+	}
+	if remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining")); err == nil {
+		rl.Remaining = remaining
+		known = true
+	}
+	if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.ResetAt = time.Unix(reset, 0).UTC()
+	}
+	if known {
+		g.mu.Lock()
+		g.rateLimit = rl
+		g.mu.Unlock()
+	}
+	return &r, nil
+}
+
+// cached returns the cached repository name.
+func (g *GraphQL) cached(name string) (*graphQLRepository, bool) {
+	if g.Cache == nil {
+		return nil, false
+	}
+	entry, ok := g.Cache.Get(cacheKey(name))
+	if !ok {
+		return nil, false
+	}
+	var r graphQLRepository
+	if err := json.Unmarshal(entry.Body, &r); err != nil {
+		return nil, false
+	}
+	return &r, true
+}
+
+// cacheKey returns the key of the repository name in the cache.
+// GraphQL responses differ from REST responses, so they have their own keys.
+func cacheKey(name string) string {
+	return "graphql:" + name
+}
@@ -0,0 +1,210 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// graphQLRepositoryJSON is a shortened repository of a GraphQL response.
+const graphQLRepositoryJSON = `{
+	"licenseInfo": {"spdxId": "MIT"},
+	"repositoryTopics": {"nodes": [{"topic": {"name": "github"}}, {"topic": {"name": "trending"}}]},
+	"homepageUrl": "https://pkg.go.dev/github.com/andygrunwald/go-trending",
+	"isArchived": true,
+	"issues": {"totalCount": 3},
+	"pullRequests": {"totalCount": 2},
+	"stargazerCount": 140,
+	"forkCount": 20,
+	"createdAt": "2015-03-10T18:55:10Z",
+	"pushedAt": "2023-05-01T07:00:00Z"
+}`
+
+// graphQLRequest is a query sent to the test server.
+type graphQLRequest struct {
+	Query     string            `json:"query"`
+	Variables map[string]string `json:"variables"`
+}
+
+// newTestGraphQL returns a GraphQL enricher that talks to a test server answering with handler.
+func newTestGraphQL(t *testing.T, handler http.Handler) *GraphQL {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	g := NewGraphQL("s3cr3t")
+	g.Endpoint, _ = url.Parse(server.URL + "/api/graphql")
+	return g
+}
+
+// answer returns a handler that answers every query with the repositories of the variables.
+// Repositories in missing are answered with a NOT_FOUND error.
+func answer(t *testing.T, queries *[]graphQLRequest, remaining int, missing ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding the query: %v", err)
+		}
+		*queries = append(*queries, req)
+
+		data := map[string]any{
+			"rateLimit": map[string]any{"cost": 1, "remaining": remaining, "resetAt": "2099-01-01T00:00:00Z"},
+		}
+		var errs []map[string]any
+		for i := 0; ; i++ {
+			owner, ok := req.Variables["o"+strconv.Itoa(i)]
+			if !ok {
+				break
+			}
+			alias := "r" + strconv.Itoa(i)
+			name := owner + "/" + req.Variables["n"+strconv.Itoa(i)]
+			if slices.Contains(missing, name) {
+				data[alias] = nil
+				errs = append(errs, map[string]any{"type": "NOT_FOUND", "path": []string{alias}, "message": "Could not resolve to a Repository with the name '" + name + "'."})
+				continue
+			}
+			data[alias] = map[string]any{"repositoryTopics": map[string]any{"nodes": []any{map[string]any{"topic": map[string]string{"name": name}}}}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data, "errors": errs})
+	}
+}
+
+func TestGraphQL_Repositories(t *testing.T) {
+	var header http.Header
+	var req graphQLRequest
+	g := newTestGraphQL(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+		header = r.Header
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("X-RateLimit-Remaining", "4990")
+		w.Header().Set("X-RateLimit-Reset", "4070908800")
+		w.Write([]byte(`{"data": {"rateLimit": {"cost": 1, "remaining": 4991, "resetAt": "2099-01-01T00:00:00Z"}, "r0": ` + graphQLRepositoryJSON + `}}`))
+	}))
+
+	got, err := g.Repositories(context.Background(), []string{"andygrunwald/go-trending"})
+	if err != nil {
+		t.Fatalf("Repositories returned error: %v", err)
+	}
+
+	want := map[string]*trending.Enrichment{
+		"andygrunwald/go-trending": {
+			License:    "MIT",
+			Topics:     []string{"github", "trending"},
+			Homepage:   "https://pkg.go.dev/github.com/andygrunwald/go-trending",
+			Archived:   true,
+			OpenIssues: 5,
+			TotalStars: 140,
+			Forks:      20,
+			CreatedAt:  time.Date(2015, 3, 10, 18, 55, 10, 0, time.UTC),
+			PushedAt:   time.Date(2023, 5, 1, 7, 0, 0, 0, time.UTC),
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Repositories returned %+v, want %+v", got, want)
+	}
+
+	if got := header.Get("Authorization"); got != "Bearer s3cr3t" {
+		t.Errorf("Repositories sent Authorization %q, want %q", got, "Bearer s3cr3t")
+	}
+	if !strings.Contains(req.Query, "r0: repository(owner: $o0, name: $n0)") || req.Variables["o0"] != "andygrunwald" || req.Variables["n0"] != "go-trending" {
+		t.Errorf("Repositories sent the query %q with %v, want the repository as variables", req.Query, req.Variables)
+	}
+
+	// The headers take precedence over the rateLimit field
+	wantRateLimit := RateLimit{Cost: 1, Remaining: 4990, ResetAt: time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if got := g.RateLimit(); got != wantRateLimit {
+		t.Errorf("RateLimit returned %+v, want %+v", got, wantRateLimit)
+	}
+}
+
+func TestGraphQL_Projects(t *testing.T) {
+	var queries []graphQLRequest
+	g := newTestGraphQL(t, answer(t, &queries, 5000, "d/missing"))
+	g.BatchSize = 2
+	g.Cache = trending.NewMemoryCache(time.Minute)
+
+	var projects []trending.Project
+	for _, name := range []string{"a/one", "b/two", "c/three", "d/missing", "e/five"} {
+		owner, repository, _ := strings.Cut(name, "/")
+		projects = append(projects, trending.Project{Name: name, Owner: owner, RepositoryName: repository})
+	}
+
+	err := g.Projects(context.Background(), projects)
+	if err == nil || !strings.Contains(err.Error(), "d/missing: Could not resolve") {
+		t.Errorf("Projects returned error %v, want a failed lookup of d/missing", err)
+	}
+
+	for _, p := range projects {
+		switch {
+		case p.Name == "d/missing" && p.Enrichment != nil:
+			t.Errorf("Projects enriched %s with %+v, want nil", p.Name, p.Enrichment)
+		case p.Name != "d/missing" && (p.Enrichment == nil || !reflect.DeepEqual(p.Enrichment.Topics, []string{p.Name})):
+			t.Errorf("Projects enriched %s with %+v, want the topic %s", p.Name, p.Enrichment, p.Name)
+		}
+	}
+	if len(queries) != 3 {
+		t.Errorf("Projects sent %d queries, want 3 batches of at most 2", len(queries))
+	}
+
+	// The second run is served from the cache, except the missing project
+	queries = nil
+	g.Projects(context.Background(), projects)
+	if len(queries) != 1 || len(queries[0].Variables) != 2 || queries[0].Variables["o0"] != "d" {
+		t.Errorf("Projects sent %+v in the second run, want only d/missing", queries)
+	}
+}
+
+func TestGraphQL_RateLimited(t *testing.T) {
+	// The first query uses up the rate limit, the second isn't sent
+	var queries []graphQLRequest
+	g := newTestGraphQL(t, answer(t, &queries, 0))
+	g.BatchSize = 1
+
+	enrichments, err := g.Repositories(context.Background(), []string{"a/one", "b/two"})
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.Remaining != 0 {
+		t.Errorf("Repositories returned error %v, want a *RateLimitError", err)
+	}
+	if len(queries) != 1 || len(enrichments) != 1 {
+		t.Errorf("Repositories sent %d queries and returned %d repositories, want 1 and 1", len(queries), len(enrichments))
+	}
+
+	// An exhausted rate limit is a 403 with the reset time in the headers
+	reset := time.Now().Add(time.Minute).Unix()
+	g = newTestGraphQL(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	_, err = g.Repositories(context.Background(), []string{"a/one"})
+	var statusErr *trending.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden || statusErr.RetryAfter == "" {
+		t.Errorf("Repositories returned error %v, want a *trending.StatusError with 403 and RetryAfter", err)
+	}
+}
+
+func TestGraphQL_QueryError(t *testing.T) {
+	g := newTestGraphQL(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors": [{"message": "Parse error on \"}\" (RCURLY) at [1, 2]"}]}`))
+	}))
+
+	_, err := g.Repositories(context.Background(), []string{"a/one", "invalid"})
+	if err == nil || !strings.Contains(err.Error(), "Parse error") || !strings.Contains(err.Error(), "invalid: invalid repository name") {
+		t.Errorf("Repositories returned error %v, want the query error and the invalid name", err)
+	}
+}
@@ -0,0 +1,61 @@
+package trending
+
+import "time"
+
+// Enrichment is additional data of a repository that is not part of the trending page,
+// like the license or the topics. It is provided by the GitHub API, see package enrich.
+type Enrichment struct {
+	// License is the SPDX identifier of the license like "MIT" or "Apache-2.0".
+	// It is "NOASSERTION" for licenses GitHub can't identify and empty if the repository has no license.
+	License string `json:"license,omitempty"`
+
+	// Topics are the topics of the repository like "cli" or "golang".
+	Topics []string `json:"topics,omitempty"`
+
+	// Homepage is the website of the project as configured in the repository.
+	Homepage string `json:"homepage,omitempty"`
+
+	// Archived is true if the repository is archived and read-only.
+	Archived bool `json:"archived"`
+
+	// OpenIssues is the number of open issues and pull requests.
+	OpenIssues int `json:"open_issues"`
+
+	// TotalStars is the overall number of stars reported by the API, which is more recent than Project.Stars of the trending page.
+	// Project.PeriodStars are the stars within the period.
+	TotalStars int `json:"total_stars"`
+
+	// Forks is the number of forks.
+	Forks int `json:"forks"`
+
+	// CreatedAt is the time the repository was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// PushedAt is the time of the latest push to any branch.
+	PushedAt time.Time `json:"pushed_at"`
+}
+
+// DeveloperEnrichment is additional data of a developer or organisation that is not part of the trending page,
+// like the company or the number of followers. It is provided by the GitHub API, see package enrich.
+type DeveloperEnrichment struct {
+	// Company is the company of the profile like "@github".
+	Company string `json:"company,omitempty"`
+
+	// Location is the location of the profile like "Berlin, Germany".
+	Location string `json:"location,omitempty"`
+
+	// Bio is the short biography of the profile.
+	Bio string `json:"bio,omitempty"`
+
+	// Blog is the website of the profile.
+	Blog string `json:"blog,omitempty"`
+
+	// Followers is the number of followers.
+	Followers int `json:"followers"`
+
+	// PublicRepos is the number of public repositories.
+	PublicRepos int `json:"public_repos"`
+
+	// CreatedAt is the time the account was created.
+	CreatedAt time.Time `json:"created_at"`
+}
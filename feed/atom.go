@@ -0,0 +1,87 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// atomFeed is the root element of an Atom 1.0 document.
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Subtitle  string      `xml:"subtitle,omitempty"`
+	Updated   string      `xml:"updated"`
+	Links     []atomLink  `xml:"link"`
+	Author    atomPerson  `xml:"author"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     *atomPerson    `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    string         `xml:"summary,omitempty"`
+}
+
+// writeAtom writes doc as Atom 1.0 document.
+func writeAtom(w io.Writer, doc *document) error {
+	updated := doc.updated.UTC().Format(time.RFC3339)
+	feed := atomFeed{
+		ID:        doc.id,
+		Title:     doc.title,
+		Updated:   updated,
+		Links:     []atomLink{{Href: doc.link, Rel: "alternate", Type: "text/html"}},
+		Author:    atomPerson{Name: "GitHub", URI: "https://github.com"},
+		Generator: generator,
+	}
+	if doc.description != doc.title {
+		feed.Subtitle = doc.description
+	}
+	if len(doc.feedURL) > 0 {
+		feed.Links = append(feed.Links, atomLink{Href: doc.feedURL, Rel: "self", Type: "application/atom+xml"})
+	}
+
+	for _, it := range doc.items {
+		e := atomEntry{
+			ID:        it.id,
+			Title:     it.title,
+			Published: it.published.UTC().Format(time.RFC3339),
+			Updated:   updated,
+			Summary:   it.summary,
+		}
+		if len(it.link) > 0 {
+			e.Links = append(e.Links, atomLink{Href: it.link, Rel: "alternate", Type: "text/html"})
+		}
+		if len(it.authorName) > 0 {
+			e.Author = &atomPerson{Name: it.authorName, URI: it.authorURL}
+		}
+		for _, tag := range it.tags {
+			e.Categories = append(e.Categories, atomCategory{Term: tag})
+		}
+		feed.Entries = append(feed.Entries, e)
+	}
+
+	return writeXML(w, feed)
+}
@@ -0,0 +1,281 @@
+// Package feed turns trending results into RSS 2.0, Atom 1.0 and JSON Feed 1.1 documents.
+//
+//	result, err := client.FetchProjects(trending.Query{Since: trending.TimeToday, Language: "go"})
+//	if err != nil {
+//		...
+//	}
+//	err = feed.Projects(os.Stdout, feed.Atom, result, feed.Options{})
+//
+// Every item has a stable GUID based on the repository (or developer) name, the period and
+// the date of the result, like
+//
+//	tag:github.com,2008:trending/repositories/daily/2026-10-18/andygrunwald/go-trending
+//
+// For GitHub Enterprise, the host of Options.BaseURL replaces github.com.
+//
+// The date is bucketed by the period of the query: a day for daily, an ISO week (2026-W42)
+// for weekly and a month (2026-10) for monthly results. Polling a feed several times within
+// the same period yields the same GUIDs, so feed readers don't notify about a project twice.
+package feed
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// Supported feed formats.
+const (
+	// RSS is an RSS 2.0 document.
+	RSS = "rss"
+	// Atom is an Atom 1.0 (RFC 4287) document.
+	Atom = "atom"
+	// JSON is a JSON Feed 1.1 document.
+	JSON = "json"
+)
+
+// generator is the name of the software written into the feeds.
+const generator = "go-trending"
+
+// Options configure a feed.
+type Options struct {
+	// Title is the title of the feed.
+	// Defaults to a title derived from the query like "Trending repositories on GitHub today (go)".
+	Title string
+
+	// Description is the description of the feed.
+	// Defaults to the title.
+	Description string
+
+	// Link is the website the feed belongs to.
+	// Defaults to the GitHub trending page of the query.
+	Link string
+
+	// FeedURL is the address the feed itself is available at.
+	// It is optional, but recommended by all formats so readers can discover updates of the address.
+	FeedURL string
+
+	// BaseURL is the address of GitHub the result was fetched from, usually trending.Trending.BaseURL.
+	// It is used for the default Link and the GUIDs. Defaults to https://github.com/.
+	BaseURL *url.URL
+}
+
+// defaultBaseURL is the default of Options.BaseURL.
+var defaultBaseURL = &url.URL{Scheme: "https", Host: "github.com", Path: "/"}
+
+// document is the format independent representation of a feed.
+type document struct {
+	baseURL     *url.URL
+	id          string
+	title       string
+	description string
+	link        string
+	feedURL     string
+	updated     time.Time
+	items       []item
+}
+
+// item is a single entry of a feed.
+type item struct {
+	id         string
+	title      string
+	link       string
+	summary    string
+	published  time.Time
+	authorName string
+	authorURL  string
+	image      string
+	tags       []string
+}
+
+// writers maps the formats to their implementation.
+var writers = map[string]func(w io.Writer, doc *document) error{
+	RSS:  writeRSS,
+	Atom: writeAtom,
+	JSON: writeJSON,
+}
+
+// contentTypes maps the formats to their media type.
+var contentTypes = map[string]string{
+	RSS:  "application/rss+xml; charset=utf-8",
+	Atom: "application/atom+xml; charset=utf-8",
+	JSON: "application/feed+json; charset=utf-8",
+}
+
+// Formats returns the names of all supported formats in alphabetical order.
+func Formats() []string {
+	names := make([]string, 0, len(writers))
+	for name := range writers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ContentType returns the media type of format like "application/atom+xml; charset=utf-8".
+// It returns an empty string for unknown formats.
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// Projects writes a feed of the projects in r to w.
+func Projects(w io.Writer, format string, r *trending.ProjectsResult, opts Options) error {
+	write, err := writer(format)
+	if err != nil {
+		return err
+	}
+
+	doc := newDocument("repositories", r.Query, r.FetchedAt, opts)
+	for _, p := range r.Projects {
+		summary := p.Description
+		if len(summary) > 0 {
+			summary += "\n\n"
+		}
+		summary += strconv.Itoa(p.PeriodStars) + " stars " + shared.Period(r.Query.Since)
+		if len(p.Language) > 0 {
+			summary = p.Language + " · " + summary
+		}
+
+		it := item{
+			id:         guid(doc.baseURL, "repositories", r.Query.Since, p.Name, r.FetchedAt),
+			title:      p.Name,
+			link:       shared.URLString(p.URL),
+			summary:    summary,
+			published:  r.FetchedAt,
+			authorName: p.Owner,
+		}
+		if p.URL != nil && len(p.Owner) > 0 {
+			it.authorURL = (&url.URL{Scheme: p.URL.Scheme, Host: p.URL.Host, Path: "/" + p.Owner}).String()
+		}
+		if len(p.Language) > 0 {
+			it.tags = []string{p.Language}
+		}
+		doc.items = append(doc.items, it)
+	}
+	return write(w, doc)
+}
+
+// Developers writes a feed of the developers in r to w.
+func Developers(w io.Writer, format string, r *trending.DevelopersResult, opts Options) error {
+	write, err := writer(format)
+	if err != nil {
+		return err
+	}
+
+	doc := newDocument("developers", r.Query, r.FetchedAt, opts)
+	for _, d := range r.Developers {
+		title := d.DisplayName
+		if len(d.FullName) > 0 {
+			title = d.FullName + " (" + d.DisplayName + ")"
+		}
+
+		doc.items = append(doc.items, item{
+			id:         guid(doc.baseURL, "developers", r.Query.Since, d.DisplayName, r.FetchedAt),
+			title:      title,
+			link:       shared.URLString(d.URL),
+			summary:    "Trending developer on GitHub " + shared.Period(r.Query.Since),
+			published:  r.FetchedAt,
+			authorName: d.DisplayName,
+			authorURL:  shared.URLString(d.URL),
+			image:      shared.URLString(d.Avatar),
+		})
+	}
+	return write(w, doc)
+}
+
+// writer returns the implementation of format.
+func writer(format string) (func(w io.Writer, doc *document) error, error) {
+	write, ok := writers[format]
+	if !ok {
+		return nil, fmt.Errorf("feed: unknown format %q, expected one of %s", format, strings.Join(Formats(), ", "))
+	}
+	return write, nil
+}
+
+// newDocument returns an empty document of kind ("repositories" or "developers") for q with defaults of opts applied.
+func newDocument(kind string, q trending.Query, fetchedAt time.Time, opts Options) *document {
+	doc := &document{
+		baseURL:     opts.BaseURL,
+		title:       opts.Title,
+		description: opts.Description,
+		link:        opts.Link,
+		feedURL:     opts.FeedURL,
+		updated:     fetchedAt,
+	}
+	if doc.baseURL == nil {
+		doc.baseURL = defaultBaseURL
+	}
+	if len(doc.title) == 0 {
+		doc.title = "Trending " + kind + " on GitHub " + shared.Period(q.Since)
+		if len(q.Language) > 0 {
+			doc.title += " (" + q.Language + ")"
+		}
+	}
+	if len(doc.description) == 0 {
+		doc.description = doc.title
+	}
+	if len(doc.link) == 0 {
+		doc.link = trendingURL(doc.baseURL, kind, q)
+	}
+	doc.id = doc.link
+	if len(doc.feedURL) > 0 {
+		doc.id = doc.feedURL
+	}
+	return doc
+}
+
+// guid returns the stable identifier of the entry name in a result of kind for the period since fetched at t
+// from the GitHub instance baseURL.
+func guid(baseURL *url.URL, kind, since, name string, t time.Time) string {
+	if len(since) == 0 {
+		since = trending.TimeToday
+	}
+	return "tag:" + baseURL.Hostname() + ",2008:trending/" + kind + "/" + since + "/" + periodBucket(since, t) + "/" + name
+}
+
+// periodBucket returns the date of t with the granularity of the period since,
+// like "2026-10-18" (daily), "2026-W42" (weekly) or "2026-10" (monthly).
+func periodBucket(since string, t time.Time) string {
+	t = t.UTC()
+	switch since {
+	case trending.TimeWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case trending.TimeMonth:
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+// trendingURL returns the address of the trending page of q at the GitHub instance baseURL.
+func trendingURL(baseURL *url.URL, kind string, q trending.Query) string {
+	path := "trending"
+	if kind == "developers" {
+		path += "/developers"
+	}
+	u := baseURL.ResolveReference(&url.URL{Path: path})
+
+	v := url.Values{}
+	if len(q.Since) > 0 {
+		v.Set("since", q.Since)
+	}
+	if len(q.Language) > 0 {
+		v.Set("l", q.Language)
+	}
+	if len(q.SpokenLanguage) > 0 && kind == "repositories" {
+		v.Set("spoken_language_code", q.SpokenLanguage)
+	}
+	if q.Sponsorable && kind == "developers" {
+		v.Set("sponsorable", "1")
+	}
+	u.RawQuery = v.Encode()
+	return u.String()
+}
@@ -0,0 +1,171 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/testutil"
+)
+
+// fetchedAt is the fixed fetch time of the results in the golden files.
+var fetchedAt = time.Date(2026, time.October, 18, 7, 30, 0, 0, time.UTC)
+
+// testResults returns the projects and developers of the fixtures for q.
+func testResults(t *testing.T, q trending.Query) (*trending.ProjectsResult, *trending.DevelopersResult) {
+	t.Helper()
+
+	client := testutil.FixtureClient()
+	projects, err := client.FetchProjects(q)
+	if err != nil {
+		t.Fatalf("FetchProjects returned error: %v", err)
+	}
+	developers, err := client.FetchDevelopers(q)
+	if err != nil {
+		t.Fatalf("FetchDevelopers returned error: %v", err)
+	}
+
+	projects.FetchedAt = fetchedAt
+	developers.FetchedAt = fetchedAt
+	return projects, developers
+}
+
+// The fixtures are the daily trending pages, so the feeds show the stars of today.
+func TestFeed_Golden(t *testing.T) {
+	projects, developers := testResults(t, trending.Query{Since: trending.TimeToday, Language: "go"})
+	opts := Options{FeedURL: "https://example.com/feed"}
+
+	for _, format := range Formats() {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Projects(&buf, format, projects, opts); err != nil {
+				t.Fatalf("Projects returned error: %v", err)
+			}
+			testutil.Golden(t, "projects."+format, buf.Bytes())
+
+			buf.Reset()
+			if err := Developers(&buf, format, developers, opts); err != nil {
+				t.Fatalf("Developers returned error: %v", err)
+			}
+			testutil.Golden(t, "developers."+format, buf.Bytes())
+		})
+	}
+}
+
+func TestFeed_WellFormed(t *testing.T) {
+	projects, _ := testResults(t, trending.Query{})
+
+	for _, format := range []string{RSS, Atom} {
+		var buf bytes.Buffer
+		if err := Projects(&buf, format, projects, Options{}); err != nil {
+			t.Fatalf("Projects(%s) returned error: %v", format, err)
+		}
+
+		dec := xml.NewDecoder(&buf)
+		for {
+			_, err := dec.Token()
+			if err != nil {
+				if err.Error() != "EOF" {
+					t.Errorf("Projects(%s) returned malformed XML: %v", format, err)
+				}
+				break
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := Projects(&buf, JSON, projects, Options{}); err != nil {
+		t.Fatalf("Projects(%s) returned error: %v", JSON, err)
+	}
+	var feed map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &feed); err != nil {
+		t.Errorf("Projects(%s) returned malformed JSON: %v", JSON, err)
+	}
+	if feed["version"] != jsonFeedVersion {
+		t.Errorf("JSON Feed version is %v, want %s", feed["version"], jsonFeedVersion)
+	}
+}
+
+func TestFeed_UnknownFormat(t *testing.T) {
+	projects, developers := testResults(t, trending.Query{})
+
+	if err := Projects(&bytes.Buffer{}, "opml", projects, Options{}); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("Projects returned error %v, want unknown format error", err)
+	}
+	if err := Developers(&bytes.Buffer{}, "opml", developers, Options{}); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("Developers returned error %v, want unknown format error", err)
+	}
+}
+
+func TestGUID(t *testing.T) {
+	morning := time.Date(2026, time.October, 18, 7, 0, 0, 0, time.UTC)
+	evening := time.Date(2026, time.October, 18, 22, 0, 0, 0, time.UTC)
+	monday := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	nextDay := time.Date(2026, time.October, 19, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		since string
+		a, b  time.Time
+		same  bool
+		want  string
+	}{
+		{"", morning, evening, true, "tag:github.com,2008:trending/repositories/daily/2026-10-18/andygrunwald/go-trending"},
+		{trending.TimeToday, morning, nextDay, false, "tag:github.com,2008:trending/repositories/daily/2026-10-18/andygrunwald/go-trending"},
+		{trending.TimeWeek, monday, morning, true, "tag:github.com,2008:trending/repositories/weekly/2026-W42/andygrunwald/go-trending"},
+		{trending.TimeWeek, morning, nextDay, false, "tag:github.com,2008:trending/repositories/weekly/2026-W42/andygrunwald/go-trending"},
+		{trending.TimeMonth, monday, nextDay, true, "tag:github.com,2008:trending/repositories/monthly/2026-10/andygrunwald/go-trending"},
+	}
+
+	for _, tt := range tests {
+		if got := guid(defaultBaseURL, "repositories", tt.since, "andygrunwald/go-trending", tt.a); got != tt.want {
+			t.Errorf("guid(%q, %v) returned %s, want %s", tt.since, tt.a, got, tt.want)
+		}
+		a := guid(defaultBaseURL, "repositories", tt.since, "andygrunwald/go-trending", tt.a)
+		b := guid(defaultBaseURL, "repositories", tt.since, "andygrunwald/go-trending", tt.b)
+		if (a == b) != tt.same {
+			t.Errorf("guid(%q) of %v and %v equal is %v, want %v", tt.since, tt.a, tt.b, a == b, tt.same)
+		}
+	}
+}
+
+func TestFeed_BaseURL(t *testing.T) {
+	projects, _ := testResults(t, trending.Query{Since: trending.TimeToday})
+	baseURL, _ := url.Parse("https://github.example.com/")
+
+	var buf bytes.Buffer
+	if err := Projects(&buf, JSON, projects, Options{BaseURL: baseURL}); err != nil {
+		t.Fatalf("Projects returned error: %v", err)
+	}
+	var doc struct {
+		HomePageURL string `json:"home_page_url"`
+		Items       []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Decoding the JSON feed failed: %v", err)
+	}
+
+	if want := "https://github.example.com/trending?since=daily"; doc.HomePageURL != want {
+		t.Errorf("Projects linked %s, want %s", doc.HomePageURL, want)
+	}
+	if want := "tag:github.example.com,2008:trending/repositories/daily/"; len(doc.Items) == 0 || !strings.HasPrefix(doc.Items[0].ID, want) {
+		t.Errorf("Projects returned items %+v, want GUIDs starting with %s", doc.Items, want)
+	}
+}
+
+func TestContentType(t *testing.T) {
+	for _, format := range Formats() {
+		if len(ContentType(format)) == 0 {
+			t.Errorf("ContentType(%q) returned an empty string", format)
+		}
+	}
+	if got := ContentType("opml"); got != "" {
+		t.Errorf("ContentType(%q) returned %q, want an empty string", "opml", got)
+	}
+}
@@ -0,0 +1,70 @@
+package feed
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonFeedVersion is the URL of the JSON Feed version written by writeJSON.
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+// jsonFeed is a JSON Feed 1.1 document.
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	Title         string           `json:"title"`
+	ContentText   string           `json:"content_text"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name   string `json:"name"`
+	URL    string `json:"url,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+// writeJSON writes doc as JSON Feed 1.1 document.
+func writeJSON(w io.Writer, doc *document) error {
+	feed := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       doc.title,
+		HomePageURL: doc.link,
+		FeedURL:     doc.feedURL,
+		Description: doc.description,
+		Items:       []jsonFeedItem{},
+	}
+
+	for _, it := range doc.items {
+		item := jsonFeedItem{
+			ID:            it.id,
+			URL:           it.link,
+			Title:         it.title,
+			ContentText:   it.summary,
+			Image:         it.image,
+			DatePublished: it.published.UTC().Format(time.RFC3339),
+			Tags:          it.tags,
+		}
+		if len(it.authorName) > 0 {
+			item.Authors = []jsonFeedAuthor{{Name: it.authorName, URL: it.authorURL, Avatar: it.image}}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(feed)
+}
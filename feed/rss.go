@@ -0,0 +1,91 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// rssDocument is the root element of an RSS 2.0 document.
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr,omitempty"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	Description   string       `xml:"description"`
+	AtomLink      *rssAtomLink `xml:"atom:link"`
+	LastBuildDate string       `xml:"lastBuildDate"`
+	Generator     string       `xml:"generator"`
+	Items         []rssItem    `xml:"item"`
+}
+
+// rssAtomLink is the self link recommended by the RSS Advisory Board.
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link,omitempty"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// writeRSS writes doc as RSS 2.0 document.
+func writeRSS(w io.Writer, doc *document) error {
+	rss := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         doc.title,
+			Link:          doc.link,
+			Description:   doc.description,
+			LastBuildDate: doc.updated.UTC().Format(time.RFC1123Z),
+			Generator:     generator,
+		},
+	}
+	if len(doc.feedURL) > 0 {
+		rss.AtomNS = "http://www.w3.org/2005/Atom"
+		rss.Channel.AtomLink = &rssAtomLink{Href: doc.feedURL, Rel: "self", Type: "application/rss+xml"}
+	}
+
+	for _, it := range doc.items {
+		rss.Channel.Items = append(rss.Channel.Items, rssItem{
+			Title:       it.title,
+			Link:        it.link,
+			Description: it.summary,
+			Categories:  it.tags,
+			GUID:        rssGUID{IsPermaLink: "false", Value: it.id},
+			PubDate:     it.published.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	return writeXML(w, rss)
+}
+
+// writeXML writes v as indented XML document including the XML header.
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
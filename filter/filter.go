@@ -0,0 +1,249 @@
+// Package filter selects and sorts trending projects and developers with a small expression language.
+//
+//	f, err := filter.ParseProjects(`stars > 500 && language in ("Go", "Rust") && !(owner in blocklist)`, filter.Options{
+//		Lists: map[string][]string{"blocklist": {"spammer", "another-spammer"}},
+//	})
+//	if err != nil {
+//		...
+//	}
+//	projects = f.Apply(projects)
+//
+// An expression compares fields with values and combines the comparisons with && (and), || (or) and ! (not).
+// Parentheses group expressions. The operators are:
+//
+//	==  !=                      equal, not equal
+//	<  <=  >  >=                numeric comparison, only for numeric fields
+//	in ("a", "b") / in list     one of the values, or of the named list of Options.Lists
+//	contains "text"             the field contains the text
+//	matches "regexp"            the field matches the regular expression (Go syntax, see package regexp)
+//
+// String comparisons (==, !=, in, contains) ignore case, so language == "go" selects "Go" as well.
+// Use (?i) to make a regular expression case insensitive.
+// The keywords and, or and not can be used instead of &&, || and !.
+//
+// Fields of projects are rank, name, owner, repository, description, language, stars (overall), period_stars (gained within the period), url and contributors (the number of contributors).
+// Fields of developers are rank, id, display_name (or login), full_name and url.
+// The rank is the 1-based position on the trending page, see ApplyRanks for results that are already filtered.
+//
+// SortProjects and SortDevelopers sort by a list of the same fields like "-stars,name".
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// Options configures the parsing of an expression.
+type Options struct {
+	// Lists are named lists of values, which can be used with the in operator like `owner in blocklist`.
+	Lists map[string][]string
+}
+
+// Filter is a parsed expression that selects items of type T, trending.Project or trending.Developer.
+type Filter[T any] struct {
+	expr  string
+	match func(rank int, item T) bool
+}
+
+// ParseProjects parses the expression expr for projects.
+// An empty expression matches every project.
+func ParseProjects(expr string, opts Options) (*Filter[trending.Project], error) {
+	return parse(expr, projectFields, opts)
+}
+
+// ParseDevelopers parses the expression expr for developers.
+// An empty expression matches every developer.
+func ParseDevelopers(expr string, opts Options) (*Filter[trending.Developer], error) {
+	return parse(expr, developerFields, opts)
+}
+
+// String returns the expression of f.
+func (f *Filter[T]) String() string {
+	return f.expr
+}
+
+// Match reports whether item at the 1-based position rank is selected by f.
+func (f *Filter[T]) Match(rank int, item T) bool {
+	return f.match(rank, item)
+}
+
+// Apply returns the items selected by f in their original order.
+// The rank of an item is its position in items, see ApplyRanks if items is only a part of the trending page.
+func (f *Filter[T]) Apply(items []T) []T {
+	result, _ := f.ApplyRanks(items, nil)
+	return result
+}
+
+// ApplyRanks returns the items selected by f in their original order and their ranks.
+// ranks are the positions of items on the trending page, like the ranks of the projects a blocklist kept:
+// the item at index i has the rank ranks[i]. If ranks is nil, the rank of an item is its position in items.
+func (f *Filter[T]) ApplyRanks(items []T, ranks []int) ([]T, []int) {
+	var result []T
+	var resultRanks []int
+	for i, item := range items {
+		rank := i + 1
+		if ranks != nil {
+			rank = ranks[i]
+		}
+		if f.match(rank, item) {
+			result = append(result, item)
+			resultRanks = append(resultRanks, rank)
+		}
+	}
+	return result, resultRanks
+}
+
+// fieldType is the type of the value of a field.
+type fieldType int
+
+const (
+	stringField fieldType = iota
+	intField
+)
+
+// field is a single named value of an item of type T.
+type field[T any] struct {
+	typ fieldType
+	str func(rank int, item T) string
+	int func(rank int, item T) int
+}
+
+// stringValue returns a string field.
+func stringValue[T any](value func(rank int, item T) string) field[T] {
+	return field[T]{typ: stringField, str: value}
+}
+
+// intValue returns a numeric field.
+func intValue[T any](value func(rank int, item T) int) field[T] {
+	return field[T]{typ: intField, int: value}
+}
+
+// projectFields are all fields of trending.Project.
+var projectFields = map[string]field[trending.Project]{
+	"rank":         intValue(func(rank int, p trending.Project) int { return rank }),
+	"name":         stringValue(func(rank int, p trending.Project) string { return p.Name }),
+	"owner":        stringValue(func(rank int, p trending.Project) string { return p.Owner }),
+	"repository":   stringValue(func(rank int, p trending.Project) string { return p.RepositoryName }),
+	"description":  stringValue(func(rank int, p trending.Project) string { return p.Description }),
+	"language":     stringValue(func(rank int, p trending.Project) string { return p.Language }),
+	"stars":        intValue(func(rank int, p trending.Project) int { return p.Stars }),
+	"period_stars": intValue(func(rank int, p trending.Project) int { return p.PeriodStars }),
+	"url":          stringValue(func(rank int, p trending.Project) string { return shared.URLString(p.URL) }),
+	"contributors": intValue(func(rank int, p trending.Project) int { return len(p.Contributor) }),
+}
+
+// developerFields are all fields of trending.Developer.
+var developerFields = map[string]field[trending.Developer]{
+	"rank":         intValue(func(rank int, d trending.Developer) int { return rank }),
+	"id":           intValue(func(rank int, d trending.Developer) int { return d.ID }),
+	"display_name": stringValue(func(rank int, d trending.Developer) string { return d.DisplayName }),
+	"login":        stringValue(func(rank int, d trending.Developer) string { return d.DisplayName }),
+	"full_name":    stringValue(func(rank int, d trending.Developer) string { return d.FullName }),
+	"url":          stringValue(func(rank int, d trending.Developer) string { return shared.URLString(d.URL) }),
+}
+
+// ProjectFields returns the names of all fields of projects.
+func ProjectFields() []string {
+	return fieldNames(projectFields)
+}
+
+// DeveloperFields returns the names of all fields of developers.
+func DeveloperFields() []string {
+	return fieldNames(developerFields)
+}
+
+// fieldNames returns the sorted names of fields.
+func fieldNames[T any](fields map[string]field[T]) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SortProjects sorts projects by keys, a comma separated list of fields like "-stars,name".
+// A "-" prefix sorts in descending order. Later keys break ties of earlier keys.
+// The sort is stable and the rank of a project is its position before sorting.
+func SortProjects(projects []trending.Project, keys string) error {
+	return sortItems(projects, projectFields, keys)
+}
+
+// SortDevelopers sorts developers by keys, a comma separated list of fields like "-id".
+// See SortProjects.
+func SortDevelopers(developers []trending.Developer, keys string) error {
+	return sortItems(developers, developerFields, keys)
+}
+
+// sortKey is a single parsed key of a sort.
+type sortKey[T any] struct {
+	field      field[T]
+	descending bool
+}
+
+// sortItems sorts items by keys.
+func sortItems[T any](items []T, fields map[string]field[T], keys string) error {
+	var parsed []sortKey[T]
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.TrimSpace(key)
+		if len(key) == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(key, "-")
+		f, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("filter: unknown sort field %q, expected one of %s", name, strings.Join(fieldNames(fields), ", "))
+		}
+		parsed = append(parsed, sortKey[T]{field: f, descending: name != key})
+	}
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	// The rank is the position before sorting
+	order := make([]int, len(items))
+	for i := range items {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		for _, k := range parsed {
+			c := compare(k.field, a+1, items[a], b+1, items[b])
+			if c == 0 {
+				continue
+			}
+			if k.descending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	sorted := make([]T, len(items))
+	for i, n := range order {
+		sorted[i] = items[n]
+	}
+	copy(items, sorted)
+	return nil
+}
+
+// compare returns -1, 0 or 1 if the value of f of a is less than, equal to or greater than the one of b.
+// Strings are compared case insensitive.
+func compare[T any](f field[T], rankA int, a T, rankB int, b T) int {
+	if f.typ == intField {
+		x, y := f.int(rankA, a), f.int(rankB, b)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(f.str(rankA, a)), strings.ToLower(f.str(rankB, b)))
+}
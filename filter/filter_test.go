@@ -0,0 +1,162 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// testProjects are the projects the tests filter, in rank order.
+var testProjects = []trending.Project{
+	{Name: "golang/go", Owner: "golang", RepositoryName: "go", Language: "Go", Stars: 900, Description: "The Go programming language"},
+	{Name: "rust-lang/rust", Owner: "rust-lang", RepositoryName: "rust", Language: "Rust", Stars: 700, Description: "Empowering everyone"},
+	{Name: "spammer/airdrop", Owner: "spammer", RepositoryName: "airdrop", Language: "Go", Stars: 600, Description: "Free CRYPTO airdrop"},
+	{Name: "someone/awesome-list", Owner: "someone", RepositoryName: "awesome-list", Stars: 400, Contributor: []trending.Developer{{DisplayName: "a"}, {DisplayName: "b"}}},
+	{Name: "old/archived-tool", Owner: "old", RepositoryName: "archived-tool", Language: "Python", Stars: 100},
+}
+
+// names returns the names of projects.
+func names(projects []trending.Project) []string {
+	var result []string
+	for _, p := range projects {
+		result = append(result, p.Name)
+	}
+	return result
+}
+
+func TestParseProjects(t *testing.T) {
+	opts := Options{Lists: map[string][]string{"blocklist": {"Spammer", "nobody"}, "ranks": {"1", "5"}}}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{expr: "", want: names(testProjects)},
+		{expr: `stars > 500 && language in ("Go","Rust") && !owner in blocklist`, want: []string{"golang/go", "rust-lang/rust"}},
+		{expr: `stars >= 600`, want: []string{"golang/go", "rust-lang/rust", "spammer/airdrop"}},
+		{expr: `stars < 400 || rank == 1`, want: []string{"golang/go", "old/archived-tool"}},
+		{expr: `stars <= 400 and not (language == "")`, want: []string{"old/archived-tool"}},
+		{expr: `language == "go"`, want: []string{"golang/go", "spammer/airdrop"}},
+		{expr: `language != 'Go'`, want: []string{"rust-lang/rust", "someone/awesome-list", "old/archived-tool"}},
+		{expr: `description contains "crypto"`, want: []string{"spammer/airdrop"}},
+		{expr: `!(name matches "(?i)archived|deprecated")`, want: []string{"golang/go", "rust-lang/rust", "spammer/airdrop", "someone/awesome-list"}},
+		{expr: `repository matches "^awesome-" || contributors > 1`, want: []string{"someone/awesome-list"}},
+		{expr: `rank in ranks`, want: []string{"golang/go", "old/archived-tool"}},
+		{expr: `owner in blocklist || owner == "golang" && stars > 1000`, want: []string{"spammer/airdrop"}},
+		{expr: `(owner in blocklist || owner == "golang") && stars > 800`, want: []string{"golang/go"}},
+	}
+
+	for _, tt := range tests {
+		f, err := ParseProjects(tt.expr, opts)
+		if err != nil {
+			t.Errorf("ParseProjects(%q) returned error: %v", tt.expr, err)
+			continue
+		}
+		if got := names(f.Apply(testProjects)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseProjects(%q).Apply returned %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseProjects_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: `starz > 5`, want: `position 1: unknown field "starz"`},
+		{expr: `stars > "5"`, want: `position 9: expected a number`},
+		{expr: `name > "a"`, want: `position 6: > requires a numeric field`},
+		{expr: `stars contains "5"`, want: `contains requires a text field`},
+		{expr: `name == "a" &&`, want: `position 15: expected a field, got end of expression`},
+		{expr: `(name == "a"`, want: `expected ")"`},
+		{expr: `owner in unknown`, want: `unknown list "unknown"`},
+		{expr: `name matches "("`, want: `invalid regular expression`},
+		{expr: `name == "a`, want: `unterminated string`},
+		{expr: `name == "a" $`, want: `unexpected character '$'`},
+		{expr: `name == "a" name`, want: `unexpected "name"`},
+		{expr: `language in ("Go" "Rust")`, want: `expected "," or ")"`},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseProjects(tt.expr, Options{})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseProjects(%q) returned error %v, want %q", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestFilter_ApplyRanks(t *testing.T) {
+	f, err := ParseProjects(`rank <= 3`, Options{})
+	if err != nil {
+		t.Fatalf("ParseProjects returned error: %v", err)
+	}
+
+	// The projects at rank 1, 3 and 5, like after a blocklist removed rank 2 and 4
+	projects := []trending.Project{testProjects[0], testProjects[2], testProjects[4]}
+	got, ranks := f.ApplyRanks(projects, []int{1, 3, 5})
+	if want := []string{"golang/go", "spammer/airdrop"}; !reflect.DeepEqual(names(got), want) || !reflect.DeepEqual(ranks, []int{1, 3}) {
+		t.Errorf("ApplyRanks returned %v with ranks %v, want %v with ranks [1 3]", names(got), ranks, want)
+	}
+
+	// Without ranks, the position is the rank
+	got, ranks = f.ApplyRanks(projects, nil)
+	if len(got) != 3 || !reflect.DeepEqual(ranks, []int{1, 2, 3}) {
+		t.Errorf("ApplyRanks without ranks returned %v with ranks %v, want all with ranks [1 2 3]", names(got), ranks)
+	}
+}
+
+func TestParseDevelopers(t *testing.T) {
+	developers := []trending.Developer{
+		{ID: 1, DisplayName: "alice", FullName: "Alice Doe"},
+		{ID: 2, DisplayName: "bob", FullName: "Bob Bot"},
+	}
+
+	f, err := ParseDevelopers(`login == "BOB" || full_name contains "alice" && id != 1`, Options{})
+	if err != nil {
+		t.Fatalf("ParseDevelopers returned error: %v", err)
+	}
+	got := f.Apply(developers)
+	if len(got) != 1 || got[0].ID != 2 {
+		t.Errorf("ParseDevelopers.Apply returned %+v, want bob only", got)
+	}
+
+	if _, err := ParseDevelopers(`stars > 5`, Options{}); err == nil {
+		t.Error("ParseDevelopers accepted the project field stars")
+	}
+}
+
+func TestSortProjects(t *testing.T) {
+	projects := append([]trending.Project(nil), testProjects...)
+
+	if err := SortProjects(projects, "language, -stars"); err != nil {
+		t.Fatalf("SortProjects returned error: %v", err)
+	}
+	want := []string{"someone/awesome-list", "golang/go", "spammer/airdrop", "old/archived-tool", "rust-lang/rust"}
+	if got := names(projects); !reflect.DeepEqual(got, want) {
+		t.Errorf("SortProjects returned %v, want %v", got, want)
+	}
+
+	if err := SortProjects(projects, "-rank"); err != nil {
+		t.Fatalf("SortProjects returned error: %v", err)
+	}
+	want = []string{"rust-lang/rust", "old/archived-tool", "spammer/airdrop", "golang/go", "someone/awesome-list"}
+	if got := names(projects); !reflect.DeepEqual(got, want) {
+		t.Errorf("SortProjects by -rank returned %v, want %v", got, want)
+	}
+
+	if err := SortProjects(projects, "popularity"); err == nil || !strings.Contains(err.Error(), `unknown sort field "popularity"`) {
+		t.Errorf("SortProjects returned error %v, want an unknown sort field", err)
+	}
+}
+
+func TestSortDevelopers(t *testing.T) {
+	developers := []trending.Developer{{ID: 1, DisplayName: "b"}, {ID: 2, DisplayName: "a"}}
+	if err := SortDevelopers(developers, "login"); err != nil {
+		t.Fatalf("SortDevelopers returned error: %v", err)
+	}
+	if developers[0].ID != 2 {
+		t.Errorf("SortDevelopers returned %+v, want a first", developers)
+	}
+}
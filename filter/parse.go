@@ -0,0 +1,401 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenKind is the kind of a token of an expression.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+// token is a single lexical element of an expression.
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+
+	// pos is the 1-based position of the first character in the expression.
+	pos int
+}
+
+// String returns the token like it is shown in error messages.
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// operators are all operators, longest first so that "<=" is not read as "<".
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", ","}
+
+// comparisonOperators are the operators comparing a field with a single value.
+var comparisonOperators = map[string]bool{"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
+
+// keywordOperators are keywords that are aliases of operators.
+var keywordOperators = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"not": "!",
+}
+
+// lex splits expr into tokens.
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(expr) && rune(expr[end]) != c {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("filter: position %d: unterminated string", i+1)
+			}
+			text := expr[i : end+1]
+			value, err := unquote(text)
+			if err != nil {
+				return nil, fmt.Errorf("filter: position %d: invalid string %s: %w", i+1, text, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, value: value, pos: i + 1})
+			i = end + 1
+
+		case c >= '0' && c <= '9':
+			end := i
+			for end < len(expr) && expr[end] >= '0' && expr[end] <= '9' {
+				end++
+			}
+			if _, err := strconv.Atoi(expr[i:end]); err != nil {
+				return nil, fmt.Errorf("filter: position %d: invalid number %s", i+1, expr[i:end])
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[i:end], value: expr[i:end], pos: i + 1})
+			i = end
+
+		case unicode.IsLetter(c) || c == '_':
+			end := i
+			for end < len(expr) && (unicode.IsLetter(rune(expr[end])) || unicode.IsDigit(rune(expr[end])) || expr[end] == '_' || expr[end] == '-') {
+				end++
+			}
+			text := expr[i:end]
+			if op, ok := keywordOperators[strings.ToLower(text)]; ok {
+				tokens = append(tokens, token{kind: tokenOperator, text: text, value: op, pos: i + 1})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, text: text, value: text, pos: i + 1})
+			}
+			i = end
+
+		default:
+			found := false
+			for _, op := range operators {
+				if strings.HasPrefix(expr[i:], op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, value: op, pos: i + 1})
+					i += len(op)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("filter: position %d: unexpected character %q", i+1, c)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(expr) + 1}), nil
+}
+
+// unquote returns the value of the double or single quoted string s.
+func unquote(s string) (string, error) {
+	if s[0] == '\'' {
+		// strconv.Unquote only accepts single characters in single quotes
+		s = `"` + strings.ReplaceAll(strings.ReplaceAll(s[1:len(s)-1], `\'`, `'`), `"`, `\"`) + `"`
+	}
+	return strconv.Unquote(s)
+}
+
+// parser is a recursive descent parser for the grammar
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" or ")" | comparison
+//	comparison = field ( op value | "in" list | "contains" string | "matches" string )
+//	list       = "(" value { "," value } ")" | name of Options.Lists
+//
+// It compiles the expression directly into a match function.
+type parser[T any] struct {
+	tokens []token
+	pos    int
+	fields map[string]field[T]
+	opts   Options
+}
+
+// parse parses expr with the fields of T.
+func parse[T any](expr string, fields map[string]field[T], opts Options) (*Filter[T], error) {
+	f := &Filter[T]{
+		expr:  expr,
+		match: func(int, T) bool { return true },
+	}
+	if len(strings.TrimSpace(expr)) == 0 {
+		return f, nil
+	}
+
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser[T]{tokens: tokens, fields: fields, opts: opts}
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	f.match = match
+	return f, nil
+}
+
+// peek returns the current token.
+func (p *parser[T]) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next returns the current token and advances to the next one.
+func (p *parser[T]) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isOperator reports whether t is the operator op.
+func isOperator(t token, op string) bool {
+	return t.kind == tokenOperator && t.value == op
+}
+
+// isKeyword reports whether t is the keyword kw like "in".
+func isKeyword(t token, kw string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, kw)
+}
+
+// errorf returns an error at the position of t.
+func (p *parser[T]) errorf(t token, format string, args ...any) error {
+	return fmt.Errorf("filter: position %d: %s", t.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser[T]) parseOr() (func(int, T) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isOperator(p.peek(), "||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(rank int, item T) bool { return l(rank, item) || right(rank, item) }
+	}
+	return left, nil
+}
+
+func (p *parser[T]) parseAnd() (func(int, T) bool, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for isOperator(p.peek(), "&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(rank int, item T) bool { return l(rank, item) && right(rank, item) }
+	}
+	return left, nil
+}
+
+func (p *parser[T]) parseUnary() (func(int, T) bool, error) {
+	t := p.peek()
+	switch {
+	case isOperator(t, "!"):
+		// "!owner in list" negates the whole comparison
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(rank int, item T) bool { return !operand(rank, item) }, nil
+
+	case isOperator(t, "("):
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); !isOperator(t, ")") {
+			return nil, p.errorf(t, "expected \")\", got %s", t)
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser[T]) parseComparison() (func(int, T) bool, error) {
+	name := p.next()
+	if name.kind != tokenIdent {
+		return nil, p.errorf(name, "expected a field, got %s", name)
+	}
+	f, ok := p.fields[strings.ToLower(name.text)]
+	if !ok {
+		return nil, p.errorf(name, "unknown field %q, expected one of %s", name.text, strings.Join(fieldNames(p.fields), ", "))
+	}
+
+	op := p.next()
+	switch {
+	case isKeyword(op, "in"):
+		values, err := p.parseList(f)
+		if err != nil {
+			return nil, err
+		}
+		return inMatcher(f, values), nil
+
+	case isKeyword(op, "contains"), isKeyword(op, "matches"):
+		if f.typ != stringField {
+			return nil, p.errorf(op, "%s requires a text field, %q is numeric", strings.ToLower(op.text), name.text)
+		}
+		value := p.next()
+		if value.kind != tokenString {
+			return nil, p.errorf(value, "expected a string, got %s", value)
+		}
+		if isKeyword(op, "contains") {
+			needle := strings.ToLower(value.value)
+			return func(rank int, item T) bool {
+				return strings.Contains(strings.ToLower(f.str(rank, item)), needle)
+			}, nil
+		}
+		re, err := regexp.Compile(value.value)
+		if err != nil {
+			return nil, p.errorf(value, "invalid regular expression: %v", err)
+		}
+		return func(rank int, item T) bool { return re.MatchString(f.str(rank, item)) }, nil
+
+	case op.kind == tokenOperator && comparisonOperators[op.value]:
+		value, err := p.parseValue(f)
+		if err != nil {
+			return nil, err
+		}
+		return compareMatcher(f, op.value, value, func() error { return p.errorf(op, "%s requires a numeric field, %q is text", op.value, name.text) })
+	}
+	return nil, p.errorf(op, "expected an operator after %q, got %s", name.text, op)
+}
+
+// parseValue parses a single value for the field f: a number for numeric fields, a string for text fields.
+func (p *parser[T]) parseValue(f field[T]) (string, error) {
+	t := p.next()
+	switch {
+	case f.typ == intField && t.kind == tokenNumber:
+		return t.value, nil
+	case f.typ == stringField && t.kind == tokenString:
+		return t.value, nil
+	case f.typ == intField:
+		return "", p.errorf(t, "expected a number, got %s", t)
+	}
+	return "", p.errorf(t, "expected a string, got %s", t)
+}
+
+// parseList parses the values after "in": a list in parentheses or the name of a list of Options.Lists.
+func (p *parser[T]) parseList(f field[T]) ([]string, error) {
+	t := p.next()
+	if t.kind == tokenIdent {
+		values, ok := p.opts.Lists[t.text]
+		if !ok {
+			return nil, p.errorf(t, "unknown list %q", t.text)
+		}
+		if f.typ == intField {
+			for _, v := range values {
+				if _, err := strconv.Atoi(v); err != nil {
+					return nil, p.errorf(t, "list %q contains %q, expected numbers", t.text, v)
+				}
+			}
+		}
+		return values, nil
+	}
+	if !isOperator(t, "(") {
+		return nil, p.errorf(t, "expected a list or \"(\", got %s", t)
+	}
+
+	var values []string
+	for {
+		value, err := p.parseValue(f)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		t := p.next()
+		if isOperator(t, ")") {
+			return values, nil
+		}
+		if !isOperator(t, ",") {
+			return nil, p.errorf(t, "expected \",\" or \")\", got %s", t)
+		}
+	}
+}
+
+// inMatcher returns a match function reporting whether the field f is one of values.
+func inMatcher[T any](f field[T], values []string) func(int, T) bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+	if f.typ == intField {
+		return func(rank int, item T) bool { return set[strconv.Itoa(f.int(rank, item))] }
+	}
+	return func(rank int, item T) bool { return set[strings.ToLower(f.str(rank, item))] }
+}
+
+// compareMatcher returns a match function comparing the field f with value using op.
+// typeErr is returned if op is an ordering operator for a text field.
+func compareMatcher[T any](f field[T], op, value string, typeErr func() error) (func(int, T) bool, error) {
+	if f.typ == stringField {
+		value = strings.ToLower(value)
+		switch op {
+		case "==":
+			return func(rank int, item T) bool { return strings.ToLower(f.str(rank, item)) == value }, nil
+		case "!=":
+			return func(rank int, item T) bool { return strings.ToLower(f.str(rank, item)) != value }, nil
+		}
+		return nil, typeErr()
+	}
+
+	n, _ := strconv.Atoi(value)
+	cmp := map[string]func(a, b int) bool{
+		"==": func(a, b int) bool { return a == b },
+		"!=": func(a, b int) bool { return a != b },
+		"<":  func(a, b int) bool { return a < b },
+		"<=": func(a, b int) bool { return a <= b },
+		">":  func(a, b int) bool { return a > b },
+		">=": func(a, b int) bool { return a >= b },
+	}[op]
+	return func(rank int, item T) bool { return cmp(f.int(rank, item), n) }, nil
+}
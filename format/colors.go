@@ -0,0 +1,11 @@
+package format
+
+import "github.com/andygrunwald/go-trending"
+
+// LanguageColor returns the hex color of the programing language name like "#00ADD8" for "Go".
+// The color is taken from the language catalog of the trending package (see trending.LookupLanguage).
+// If the color is unknown, an empty string is returned.
+func LanguageColor(name string) string {
+	info, _ := trending.LookupLanguage(name)
+	return info.Color
+}
@@ -0,0 +1,233 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// column extracts a single value out of an item of type T.
+// The value is either a string or an int.
+type column[T any] struct {
+	name  string
+	value func(rank int, item T) any
+}
+
+// projectColumns are all columns available for trending.Project.
+var projectColumns = []column[trending.Project]{
+	{"rank", func(rank int, p trending.Project) any { return rank }},
+	{"name", func(rank int, p trending.Project) any { return p.Name }},
+	{"owner", func(rank int, p trending.Project) any { return p.Owner }},
+	{"repository", func(rank int, p trending.Project) any { return p.RepositoryName }},
+	{"description", func(rank int, p trending.Project) any { return p.Description }},
+	{"language", func(rank int, p trending.Project) any { return p.Language }},
+	{"stars", func(rank int, p trending.Project) any { return p.Stars }},
+	{"period_stars", func(rank int, p trending.Project) any { return p.PeriodStars }},
+	{"url", func(rank int, p trending.Project) any { return shared.URLString(p.URL) }},
+	{"contributor_url", func(rank int, p trending.Project) any { return shared.URLString(p.ContributorURL) }},
+	{"contributors", func(rank int, p trending.Project) any {
+		names := make([]string, 0, len(p.Contributor))
+		for _, d := range p.Contributor {
+			names = append(names, d.DisplayName)
+		}
+		return strings.Join(names, ",")
+	}},
+	{"license", enriched(func(e *trending.Enrichment) any { return e.License }, "")},
+	{"topics", enriched(func(e *trending.Enrichment) any { return strings.Join(e.Topics, ",") }, "")},
+	{"archived", enriched(func(e *trending.Enrichment) any { return strconv.FormatBool(e.Archived) }, "")},
+	{"open_issues", enriched(func(e *trending.Enrichment) any { return e.OpenIssues }, 0)},
+	{"total_stars", enriched(func(e *trending.Enrichment) any { return e.TotalStars }, 0)},
+	{"pushed_at", enriched(func(e *trending.Enrichment) any { return formatDate(e.PushedAt) }, "")},
+}
+
+// enriched returns the value of a column of trending.Project.Enrichment.
+// zero is the value of projects that are not enriched.
+func enriched(value func(e *trending.Enrichment) any, zero any) func(rank int, p trending.Project) any {
+	return func(rank int, p trending.Project) any {
+		if p.Enrichment == nil {
+			return zero
+		}
+		return value(p.Enrichment)
+	}
+}
+
+// formatDate returns t as date like "2023-05-01" or an empty string if t is zero.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.DateOnly)
+}
+
+// developerColumns are all columns available for trending.Developer.
+var developerColumns = []column[trending.Developer]{
+	{"rank", func(rank int, d trending.Developer) any { return rank }},
+	{"id", func(rank int, d trending.Developer) any { return d.ID }},
+	{"display_name", func(rank int, d trending.Developer) any { return d.DisplayName }},
+	{"full_name", func(rank int, d trending.Developer) any { return d.FullName }},
+	{"url", func(rank int, d trending.Developer) any { return shared.URLString(d.URL) }},
+	{"avatar", func(rank int, d trending.Developer) any { return shared.URLString(d.Avatar) }},
+	{"company", enrichedDeveloper(func(e *trending.DeveloperEnrichment) any { return e.Company }, "")},
+	{"location", enrichedDeveloper(func(e *trending.DeveloperEnrichment) any { return e.Location }, "")},
+	{"bio", enrichedDeveloper(func(e *trending.DeveloperEnrichment) any { return e.Bio }, "")},
+	{"followers", enrichedDeveloper(func(e *trending.DeveloperEnrichment) any { return e.Followers }, 0)},
+	{"public_repos", enrichedDeveloper(func(e *trending.DeveloperEnrichment) any { return e.PublicRepos }, 0)},
+	{"created_at", enrichedDeveloper(func(e *trending.DeveloperEnrichment) any { return formatDate(e.CreatedAt) }, "")},
+}
+
+// enrichedDeveloper returns the value of a column of trending.Developer.Enrichment.
+// zero is the value of developers that are not enriched.
+func enrichedDeveloper(value func(e *trending.DeveloperEnrichment) any, zero any) func(rank int, d trending.Developer) any {
+	return func(rank int, d trending.Developer) any {
+		if d.Enrichment == nil {
+			return zero
+		}
+		return value(d.Enrichment)
+	}
+}
+
+// languageColumns are all columns available for trending.Language.
+var languageColumns = []column[trending.Language]{
+	{"name", func(rank int, l trending.Language) any { return l.Name }},
+	{"url_name", func(rank int, l trending.Language) any { return l.URLName }},
+	{"url", func(rank int, l trending.Language) any { return shared.URLString(l.URL) }},
+}
+
+// Default columns of the tabular formats if no columns are selected.
+var (
+	defaultProjectColumns   = []string{"rank", "name", "language", "stars", "description"}
+	defaultDeveloperColumns = []string{"rank", "display_name", "full_name", "url"}
+	defaultLanguageColumns  = []string{"name", "url_name"}
+)
+
+// ProjectColumns returns the names of all columns available for projects.
+func ProjectColumns() []string {
+	return columnNames(projectColumns)
+}
+
+// DeveloperColumns returns the names of all columns available for developers.
+func DeveloperColumns() []string {
+	return columnNames(developerColumns)
+}
+
+// LanguageColumns returns the names of all columns available for languages.
+func LanguageColumns() []string {
+	return columnNames(languageColumns)
+}
+
+// columnNames returns the names of columns.
+func columnNames[T any](columns []column[T]) []string {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// field is a single named value of a record.
+type field struct {
+	name  string
+	value any
+}
+
+// record is a single item reduced to the selected columns, in column order.
+type record []field
+
+// dataset is the format independent representation of a list of items.
+type dataset struct {
+	// columns are the names of the selected columns.
+	columns []string
+
+	// records are the items reduced to the selected columns.
+	records []record
+
+	// items are the original items in the same order as records.
+	items []any
+
+	// explicit reports whether the columns were selected by the user.
+	// If not, JSON, NDJSON and YAML write the original items.
+	explicit bool
+}
+
+// newDataset converts items into a dataset by applying the column selection and sorting of opts.
+func newDataset[T any](items []T, columns []column[T], defaults []string, opts Options) (*dataset, error) {
+	byName := make(map[string]column[T], len(columns))
+	for _, c := range columns {
+		byName[c.name] = c
+	}
+
+	selected := opts.Columns
+	if len(selected) == 0 {
+		selected = defaults
+	}
+	for _, name := range selected {
+		if _, ok := byName[name]; !ok {
+			return nil, fmt.Errorf("format: unknown column %q, expected one of %s", name, strings.Join(columnNames(columns), ", "))
+		}
+	}
+
+	// The rank is the position on GitHub and is therefore determined before sorting.
+	if len(opts.Ranks) > 0 && len(opts.Ranks) != len(items) {
+		return nil, fmt.Errorf("format: got %d ranks for %d items", len(opts.Ranks), len(items))
+	}
+	rank := func(i int) int {
+		if len(opts.Ranks) > 0 {
+			return opts.Ranks[i]
+		}
+		return i + 1
+	}
+	order := make([]int, len(items))
+	for i := range items {
+		order[i] = i
+	}
+
+	if len(opts.Sort) > 0 {
+		key := strings.TrimPrefix(opts.Sort, "-")
+		descending := key != opts.Sort
+		c, ok := byName[key]
+		if !ok {
+			return nil, fmt.Errorf("format: unknown sort column %q, expected one of %s", key, strings.Join(columnNames(columns), ", "))
+		}
+
+		sort.SliceStable(order, func(i, j int) bool {
+			a := c.value(rank(order[i]), items[order[i]])
+			b := c.value(rank(order[j]), items[order[j]])
+			if descending {
+				return less(b, a)
+			}
+			return less(a, b)
+		})
+	}
+
+	d := &dataset{
+		columns:  selected,
+		records:  make([]record, 0, len(items)),
+		items:    make([]any, 0, len(items)),
+		explicit: len(opts.Columns) > 0,
+	}
+	for _, i := range order {
+		r := make(record, 0, len(selected))
+		for _, name := range selected {
+			r = append(r, field{name: name, value: byName[name].value(rank(i), items[i])})
+		}
+		d.records = append(d.records, r)
+		d.items = append(d.items, items[i])
+	}
+
+	return d, nil
+}
+
+// less compares two column values. Ints are compared numerically, everything else case insensitive as string.
+func less(a, b any) bool {
+	ai, aok := a.(int)
+	bi, bok := b.(int)
+	if aok && bok {
+		return ai < bi
+	}
+	return strings.ToLower(fmt.Sprint(a)) < strings.ToLower(fmt.Sprint(b))
+}
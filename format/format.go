@@ -0,0 +1,143 @@
+// Package format renders trending projects, developers and languages in different output formats.
+//
+// The supported formats are a human readable table, JSON, newline delimited JSON (NDJSON),
+// CSV, Markdown and YAML. All formats support the selection of columns and sorting:
+//
+//	f, err := format.New(format.Markdown, format.Options{
+//		Columns: []string{"rank", "name", "stars"},
+//		Sort:    "-stars",
+//	})
+//	if err != nil {
+//		...
+//	}
+//	err = f.Projects(os.Stdout, projects)
+//
+// JSON, NDJSON and YAML write the complete objects (see trending.SchemaVersion)
+// unless columns are selected explicitly.
+package format
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// Names of the supported formats.
+const (
+	// Table is a column aligned plain text table for terminals.
+	Table = "table"
+	// JSON is a single indented JSON array.
+	JSON = "json"
+	// NDJSON is newline delimited JSON with one object per line.
+	NDJSON = "ndjson"
+	// CSV is comma separated values with a header line.
+	CSV = "csv"
+	// Markdown is a GitHub flavored Markdown table.
+	Markdown = "markdown"
+	// YAML is a YAML sequence.
+	YAML = "yaml"
+)
+
+// Formatter writes trending items into w.
+type Formatter interface {
+	// Projects writes projects into w.
+	Projects(w io.Writer, projects []trending.Project) error
+
+	// Developers writes developers into w.
+	Developers(w io.Writer, developers []trending.Developer) error
+
+	// Languages writes languages into w.
+	Languages(w io.Writer, languages []trending.Language) error
+}
+
+// Options configure a Formatter.
+type Options struct {
+	// Columns is the list of columns to write, in order.
+	// If empty, a default set of columns is used for tabular formats
+	// and complete objects are written for JSON, NDJSON and YAML.
+	// See ProjectColumns, DeveloperColumns and LanguageColumns for the available columns.
+	Columns []string
+
+	// Sort is the column to sort by.
+	// A leading "-" sorts in descending order, like "-stars".
+	// If empty, the order of GitHub is kept.
+	Sort string
+
+	// Ranks are the positions of the items on the trending page, if only some of them are written,
+	// like after filtering: the item at index i has the rank Ranks[i].
+	// If empty, the rank of an item is its position in the written slice.
+	Ranks []int
+}
+
+// renderFunc writes a dataset in a specific format.
+type renderFunc func(w io.Writer, d *dataset) error
+
+// renderers maps the format names to their implementation.
+var renderers = map[string]renderFunc{
+	Table:    renderTable,
+	JSON:     renderJSON,
+	NDJSON:   renderNDJSON,
+	CSV:      renderCSV,
+	Markdown: renderMarkdown,
+	YAML:     renderYAML,
+}
+
+// Names returns the names of all supported formats in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns the Formatter for the format name like "table" or "json".
+func New(name string, opts Options) (Formatter, error) {
+	render, ok := renderers[name]
+	if !ok {
+		return nil, fmt.Errorf("format: unknown format %q, expected one of %s", name, strings.Join(Names(), ", "))
+	}
+
+	f := &formatter{
+		opts:   opts,
+		render: render,
+	}
+	return f, nil
+}
+
+// formatter implements Formatter by converting the items into a dataset and rendering it.
+type formatter struct {
+	opts   Options
+	render renderFunc
+}
+
+// Projects writes projects into w.
+func (f *formatter) Projects(w io.Writer, projects []trending.Project) error {
+	d, err := newDataset(projects, projectColumns, defaultProjectColumns, f.opts)
+	if err != nil {
+		return err
+	}
+	return f.render(w, d)
+}
+
+// Developers writes developers into w.
+func (f *formatter) Developers(w io.Writer, developers []trending.Developer) error {
+	d, err := newDataset(developers, developerColumns, defaultDeveloperColumns, f.opts)
+	if err != nil {
+		return err
+	}
+	return f.render(w, d)
+}
+
+// Languages writes languages into w.
+func (f *formatter) Languages(w io.Writer, languages []trending.Language) error {
+	d, err := newDataset(languages, languageColumns, defaultLanguageColumns, f.opts)
+	if err != nil {
+		return err
+	}
+	return f.render(w, d)
+}
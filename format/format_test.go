@@ -0,0 +1,222 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/testutil"
+)
+
+func TestFormatter_Projects(t *testing.T) {
+	projects, err := testutil.FixtureClient().GetProjects(trending.TimeToday, "")
+	if err != nil {
+		t.Fatalf("GetProjects returned error: %v", err)
+	}
+	// Five projects are enough to cover the formats and keep the golden files readable
+	projects = projects[:5]
+
+	tests := []struct {
+		name   string
+		format string
+		opts   Options
+	}{
+		{name: "projects.table", format: Table},
+		{name: "projects.json", format: JSON},
+		{name: "projects.ndjson", format: NDJSON},
+		{name: "projects.csv", format: CSV},
+		{name: "projects.markdown", format: Markdown},
+		{name: "projects.yaml", format: YAML},
+		{name: "projects_sorted.table", format: Table, opts: Options{Columns: []string{"rank", "name", "stars"}, Sort: "-stars"}},
+		{name: "projects_columns.ndjson", format: NDJSON, opts: Options{Columns: []string{"name", "stars", "url"}, Sort: "name"}},
+		{name: "projects_columns.yaml", format: YAML, opts: Options{Columns: []string{"rank", "name", "contributors"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.format, tt.opts)
+			if err != nil {
+				t.Fatalf("New returned error: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := f.Projects(&buf, projects); err != nil {
+				t.Fatalf("Projects returned error: %v", err)
+			}
+			testutil.Golden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestFormatter_Developers(t *testing.T) {
+	developers, err := testutil.FixtureClient().GetDevelopers(trending.TimeToday, "")
+	if err != nil {
+		t.Fatalf("GetDevelopers returned error: %v", err)
+	}
+	developers = developers[:5]
+
+	tests := []struct {
+		name   string
+		format string
+		opts   Options
+	}{
+		{name: "developers.table", format: Table},
+		{name: "developers.json", format: JSON},
+		{name: "developers.csv", format: CSV},
+		{name: "developers.markdown", format: Markdown, opts: Options{Columns: []string{"display_name", "id"}, Sort: "id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.format, tt.opts)
+			if err != nil {
+				t.Fatalf("New returned error: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := f.Developers(&buf, developers); err != nil {
+				t.Fatalf("Developers returned error: %v", err)
+			}
+			testutil.Golden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestFormatter_Languages(t *testing.T) {
+	languages, err := testutil.FixtureClient().GetLanguages()
+	if err != nil {
+		t.Fatalf("GetLanguages returned error: %v", err)
+	}
+	languages = languages[:5]
+
+	tests := []struct {
+		name   string
+		format string
+		opts   Options
+	}{
+		{name: "languages.table", format: Table},
+		{name: "languages.yaml", format: YAML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.format, tt.opts)
+			if err != nil {
+				t.Fatalf("New returned error: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := f.Languages(&buf, languages); err != nil {
+				t.Fatalf("Languages returned error: %v", err)
+			}
+			testutil.Golden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestFormatter_EnrichedColumns(t *testing.T) {
+	projects := []trending.Project{
+		{Name: "a/one", Enrichment: &trending.Enrichment{
+			License:    "MIT",
+			Topics:     []string{"cli", "go"},
+			Archived:   true,
+			OpenIssues: 3,
+			TotalStars: 140,
+			PushedAt:   time.Date(2023, 5, 1, 7, 0, 0, 0, time.UTC),
+		}},
+		{Name: "b/two"},
+	}
+
+	f, err := New(CSV, Options{Columns: []string{"name", "license", "topics", "archived", "open_issues", "total_stars", "pushed_at"}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := f.Projects(&buf, projects); err != nil {
+		t.Fatalf("Projects returned error: %v", err)
+	}
+
+	want := "name,license,topics,archived,open_issues,total_stars,pushed_at\n" +
+		"a/one,MIT,\"cli,go\",true,3,140,2023-05-01\n" +
+		"b/two,,,,0,0,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Projects returned %q, want %q", got, want)
+	}
+}
+
+func TestFormatter_EnrichedDeveloperColumns(t *testing.T) {
+	developers := []trending.Developer{
+		{DisplayName: "alice", Enrichment: &trending.DeveloperEnrichment{
+			Company:     "@acme",
+			Location:    "Berlin",
+			Followers:   900,
+			PublicRepos: 12,
+			CreatedAt:   time.Date(2010, 6, 13, 9, 41, 29, 0, time.UTC),
+		}},
+		{DisplayName: "bob"},
+	}
+
+	f, err := New(CSV, Options{Columns: []string{"display_name", "company", "location", "followers", "public_repos", "created_at"}, Sort: "-followers"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := f.Developers(&buf, developers); err != nil {
+		t.Fatalf("Developers returned error: %v", err)
+	}
+
+	want := "display_name,company,location,followers,public_repos,created_at\n" +
+		"alice,@acme,Berlin,900,12,2010-06-13\n" +
+		"bob,,,0,0,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Developers returned %q, want %q", got, want)
+	}
+}
+
+func TestFormatter_Ranks(t *testing.T) {
+	// The projects at rank 2 and 5 of the trending page, like after filtering
+	projects := []trending.Project{{Name: "a/two", Stars: 10}, {Name: "b/five", Stars: 20}}
+
+	f, err := New(CSV, Options{Columns: []string{"rank", "name"}, Sort: "-stars", Ranks: []int{2, 5}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := f.Projects(&buf, projects); err != nil {
+		t.Fatalf("Projects returned error: %v", err)
+	}
+	if want := "rank,name\n5,b/five\n2,a/two\n"; buf.String() != want {
+		t.Errorf("Projects returned %q, want %q", buf.String(), want)
+	}
+
+	if err := f.Projects(&buf, projects[:1]); err == nil {
+		t.Error("Projects returned no error for 2 ranks of 1 project")
+	}
+}
+
+func TestNew_UnknownFormat(t *testing.T) {
+	if _, err := New("xml", Options{}); err == nil {
+		t.Error("New returned no error for unknown format \"xml\"")
+	}
+}
+
+func TestFormatter_UnknownColumn(t *testing.T) {
+	f, err := New(Table, Options{Columns: []string{"forks"}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Projects(&buf, nil); err == nil {
+		t.Error("Projects returned no error for unknown column \"forks\"")
+	}
+
+	f, err = New(Table, Options{Sort: "-forks"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if err := f.Projects(&buf, nil); err == nil {
+		t.Error("Projects returned no error for unknown sort column \"forks\"")
+	}
+}
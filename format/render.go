@@ -0,0 +1,173 @@
+package format
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalJSON encodes a record as JSON object with the keys in column order.
+func (r record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range r {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// objects returns the values that are written by the object based formats (JSON, NDJSON, YAML).
+// These are the original items, unless columns were selected explicitly.
+func (d *dataset) objects() []any {
+	if d.explicit {
+		objects := make([]any, 0, len(d.records))
+		for _, r := range d.records {
+			objects = append(objects, r)
+		}
+		return objects
+	}
+	return d.items
+}
+
+// cell returns the string representation of a single value for the tabular formats.
+func cell(value any) string {
+	return fmt.Sprint(value)
+}
+
+// renderTable writes d as column aligned plain text table.
+func renderTable(w io.Writer, d *dataset) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := make([]string, 0, len(d.columns))
+	for _, c := range d.columns {
+		header = append(header, strings.ToUpper(c))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, r := range d.records {
+		cells := make([]string, 0, len(r))
+		for _, f := range r {
+			// Tabs and newlines would break the alignment
+			cells = append(cells, strings.Join(strings.Fields(cell(f.value)), " "))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// renderJSON writes d as a single indented JSON array.
+func renderJSON(w io.Writer, d *dataset) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d.objects())
+}
+
+// renderNDJSON writes d as newline delimited JSON with one object per line.
+func renderNDJSON(w io.Writer, d *dataset) error {
+	enc := json.NewEncoder(w)
+	for _, o := range d.objects() {
+		if err := enc.Encode(o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderCSV writes d as comma separated values with a header line.
+func renderCSV(w io.Writer, d *dataset) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(d.columns); err != nil {
+		return err
+	}
+	for _, r := range d.records {
+		cells := make([]string, 0, len(r))
+		for _, f := range r {
+			cells = append(cells, cell(f.value))
+		}
+		if err := cw.Write(cells); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// markdownEscaper escapes characters that would break a Markdown table cell.
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ", "\r", " ")
+
+// renderMarkdown writes d as GitHub flavored Markdown table.
+func renderMarkdown(w io.Writer, d *dataset) error {
+	var buf bytes.Buffer
+
+	buf.WriteString("| " + strings.Join(d.columns, " | ") + " |\n")
+	buf.WriteString("|")
+	for range d.columns {
+		buf.WriteString(" --- |")
+	}
+	buf.WriteString("\n")
+
+	for _, r := range d.records {
+		cells := make([]string, 0, len(r))
+		for _, f := range r {
+			cells = append(cells, markdownEscaper.Replace(cell(f.value)))
+		}
+		buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// renderYAML writes d as YAML sequence.
+//
+// The objects are encoded as JSON first and converted into YAML afterwards.
+// This way YAML has exactly the same keys and values as JSON.
+func renderYAML(w io.Writer, d *dataset) error {
+	b, err := json.Marshal(d.objects())
+	if err != nil {
+		return err
+	}
+
+	// JSON is a subset of YAML, so it can be decoded as YAML while keeping the order of the keys
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	resetStyle(&doc)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// resetStyle removes the JSON flow and quoting style of n and all its children.
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
@@ -0,0 +1,160 @@
+package format
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/shared"
+)
+
+// builtinTemplates are the templates shipped with this package.
+// The name of a template is the file name without extension, like "weekly-digest".
+//
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// Result is the data passed to a template by Render.
+type Result struct {
+	// Query is the query used to fetch the result.
+	Query trending.Query
+
+	// Projects are the trending projects, if projects were requested.
+	Projects []trending.Project
+
+	// Developers are the trending developers, if developers were requested.
+	Developers []trending.Developer
+
+	// PreviousProjects is an older result of the same query.
+	// If set, the rankDelta function reports how the rank of a project changed since then.
+	PreviousProjects []trending.Project
+
+	// GeneratedAt is the time the result was fetched.
+	GeneratedAt time.Time
+}
+
+// TemplateNames returns the names of all built-in templates in alphabetical order.
+func TemplateNames() []string {
+	entries, _ := builtinTemplates.ReadDir("templates")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), path.Ext(e.Name())))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinTemplate returns the built-in template name, like "weekly-digest" or "slack".
+func BuiltinTemplate(name string) (*template.Template, error) {
+	b, err := builtinTemplates.ReadFile("templates/" + name + ".tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("format: unknown template %q, expected one of %s", name, strings.Join(TemplateNames(), ", "))
+	}
+	return ParseTemplate(name, string(b))
+}
+
+// ParseTemplate parses text as template with all helper functions available.
+//
+// Next to the functions of text/template the following functions can be used:
+//
+//	add a b             sum of a and b, e.g. {{ add $i 1 }} to turn an index into a rank
+//	humanize n          short form of a number like 1.2k or 3.4M
+//	languageColor name  hex color of a programing language like "#00ADD8" for Go
+//	period since        human readable period like "today" for "daily"
+//	rankDelta project   rank change since Result.PreviousProjects like "+2", "-1", "=" or "new"
+//	truncate n s        s shortened to n characters
+func ParseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs(Result{})).Parse(text)
+}
+
+// ParseTemplateFile parses the file filename as template with all helper functions available.
+// See ParseTemplate for the list of functions.
+func ParseTemplateFile(filename string) (*template.Template, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemplate(path.Base(filename), string(b))
+}
+
+// Render executes tmpl with result and writes the output into w.
+// tmpl must be created by ParseTemplate, ParseTemplateFile or BuiltinTemplate.
+func Render(w io.Writer, tmpl *template.Template, result Result) error {
+	t, err := tmpl.Clone()
+	if err != nil {
+		return err
+	}
+	return t.Funcs(templateFuncs(result)).Execute(w, result)
+}
+
+// templateFuncs returns the helper functions of the templates.
+// Functions that depend on the result, like rankDelta, are bound to result.
+func templateFuncs(result Result) template.FuncMap {
+	return template.FuncMap{
+		"add":           func(a, b int) int { return a + b },
+		"humanize":      humanize,
+		"languageColor": LanguageColor,
+		"period":        shared.Period,
+		"rankDelta": func(p trending.Project) string {
+			return rankDelta(p, result.Projects, result.PreviousProjects)
+		},
+		// The text is the last argument, so that it can be piped like {{ .Description | truncate 200 }}
+		"truncate": func(n int, s string) string { return shared.Truncate(s, n) },
+	}
+}
+
+// humanize returns a short form of n like "950", "5.1k" or "1.2M".
+func humanize(n int) string {
+	if n < 1000 && n > -1000 {
+		return strconv.Itoa(n)
+	}
+	// Round before choosing the unit, so that 999,999 becomes "1M" instead of "1000k"
+	if k := math.Round(float64(n)/100) / 10; k < 1000 && k > -1000 {
+		return trimZero(strconv.FormatFloat(k, 'f', 1, 64)) + "k"
+	}
+	return trimZero(strconv.FormatFloat(float64(n)/1000000, 'f', 1, 64)) + "M"
+}
+
+// trimZero removes a trailing ".0" from s
+func trimZero(s string) string {
+	return strings.TrimSuffix(s, ".0")
+}
+
+// rankDelta reports how the rank of p changed between previous and current.
+// It returns "+n" if p moved up n ranks, "-n" if it moved down, "=" if the rank is the same
+// and "new" if p is not part of previous. If previous is empty, an empty string is returned.
+func rankDelta(p trending.Project, current, previous []trending.Project) string {
+	if len(previous) == 0 {
+		return ""
+	}
+
+	rank := projectRank(p.Name, current)
+	before := projectRank(p.Name, previous)
+	switch {
+	case before == 0:
+		return "new"
+	case rank == before:
+		return "="
+	default:
+		return fmt.Sprintf("%+d", before-rank)
+	}
+}
+
+// projectRank returns the 1-based position of the project name in projects or 0 if it is not part of it.
+func projectRank(name string, projects []trending.Project) int {
+	for i, p := range projects {
+		if p.Name == name {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,108 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/testutil"
+)
+
+// testResult returns a Result with the first five projects and developers of the fixtures.
+// The previous projects are the same projects with rank 1 and 2 swapped and without rank 5.
+func testResult(t *testing.T) Result {
+	t.Helper()
+
+	client := testutil.FixtureClient()
+	projects, err := client.GetProjects(trending.TimeWeek, "")
+	if err != nil {
+		t.Fatalf("GetProjects returned error: %v", err)
+	}
+	developers, err := client.GetDevelopers(trending.TimeWeek, "")
+	if err != nil {
+		t.Fatalf("GetDevelopers returned error: %v", err)
+	}
+
+	projects = projects[:5]
+	previous := []trending.Project{projects[1], projects[0], projects[2], projects[3]}
+
+	return Result{
+		Query:            trending.Query{Since: trending.TimeWeek},
+		Projects:         projects,
+		Developers:       developers[:5],
+		PreviousProjects: previous,
+		GeneratedAt:      time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestRender_BuiltinTemplates(t *testing.T) {
+	result := testResult(t)
+
+	for _, name := range TemplateNames() {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := BuiltinTemplate(name)
+			if err != nil {
+				t.Fatalf("BuiltinTemplate returned error: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := Render(&buf, tmpl, result); err != nil {
+				t.Fatalf("Render returned error: %v", err)
+			}
+			testutil.Golden(t, "template_"+name, buf.Bytes())
+		})
+	}
+}
+
+func TestRender_CustomTemplate(t *testing.T) {
+	result := testResult(t)
+
+	tmpl, err := ParseTemplate("custom", `{{ range .Projects }}{{ .Name }} {{ rankDelta . }} {{ languageColor .Language }} {{ humanize .Stars }}{{ "\n" }}{{ end }}`)
+	if err != nil {
+		t.Fatalf("ParseTemplate returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, tmpl, result); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	want := "smol-ai/developer +1 #3572A5 5.1k\n" +
+		"StanGirard/quivr -1 #3572A5 1.8k\n" +
+		"sunner/ChatALL = #f1e05a 2.9k\n" +
+		"microsoft/guidance = #DA5B0B 4.5k\n" +
+		"langgenius/dify new #3178c6 2.6k\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render returned\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuiltinTemplate_Unknown(t *testing.T) {
+	if _, err := BuiltinTemplate("newsletter"); err == nil {
+		t.Error("BuiltinTemplate returned no error for unknown template \"newsletter\"")
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1k"},
+		{5096, "5.1k"},
+		{240084, "240.1k"},
+		{999_949, "999.9k"},
+		{999_999, "1M"},
+		{-999_999, "-1M"},
+		{1260000, "1.3M"},
+	}
+
+	for _, tt := range tests {
+		if got := humanize(tt.n); got != tt.want {
+			t.Errorf("humanize(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
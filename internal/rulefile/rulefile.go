@@ -0,0 +1,43 @@
+// Package rulefile reads the YAML (or JSON) files of the blocklist and watchlist packages.
+package rulefile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validator is implemented by the decoded rules, like *blocklist.Rules.
+type Validator interface {
+	Validate() error
+}
+
+// Load reads the file name and decodes it into v with Parse.
+// Errors of the content are prefixed with name.
+func Load(name, pkg string, v Validator) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := Parse(b, pkg, v); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// Parse decodes the YAML or JSON b into v and validates it.
+// Keys that are not a field of v are an error, so that a typo is reported instead of ignored.
+// An empty input leaves v unchanged. Decoding errors are prefixed with pkg like "blocklist: ",
+// errors of Validate are returned as they are.
+func Parse(b []byte, pkg string, v Validator) error {
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", pkg, err)
+	}
+	return v.Validate()
+}
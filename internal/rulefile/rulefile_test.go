@@ -0,0 +1,63 @@
+package rulefile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// rules is a minimal rule set for the tests.
+type rules struct {
+	Names []string `yaml:"names"`
+}
+
+func (r *rules) Validate() error {
+	for _, n := range r.Names {
+		if len(n) == 0 {
+			return errors.New("test: empty name")
+		}
+	}
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		names int
+		err   string
+	}{
+		{input: "", names: 0},
+		{input: "names: [a, b]", names: 2},
+		{input: `{"names": ["a"]}`, names: 1},
+		{input: "name: [a]", err: "test: yaml: unmarshal errors:\n  line 1: field name not found"},
+		{input: `names: [""]`, err: "test: empty name"},
+	}
+
+	for _, tt := range tests {
+		var r rules
+		err := Parse([]byte(tt.input), "test", &r)
+		switch {
+		case len(tt.err) > 0 && (err == nil || !strings.HasPrefix(err.Error(), tt.err)):
+			t.Errorf("Parse(%q) returned error %v, want %q", tt.input, err, tt.err)
+		case len(tt.err) == 0 && (err != nil || len(r.Names) != tt.names):
+			t.Errorf("Parse(%q) returned %d names and error %v, want %d names", tt.input, len(r.Names), err, tt.names)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(name, []byte("name: [a]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var r rules
+	if err := Load(name, "test", &r); err == nil || !strings.HasPrefix(err.Error(), name+": test: ") {
+		t.Errorf("Load returned error %v, want it prefixed with the file name", err)
+	}
+	if err := Load(filepath.Join(t.TempDir(), "missing.yaml"), "test", &r); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Load returned error %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,53 @@
+// Package shared contains helpers used by several packages of go-trending
+// to present and compare trending results in the same way.
+package shared
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// Truncate shortens s to n characters. Shortened strings end with "…".
+// If n is zero or negative, an empty string is returned.
+func Truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n < 1 {
+		return ""
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// Period returns the human readable form of the timeframe since like "today" for trending.TimeToday.
+func Period(since string) string {
+	switch since {
+	case trending.TimeWeek:
+		return "this week"
+	case trending.TimeMonth:
+		return "this month"
+	default:
+		return "today"
+	}
+}
+
+// URLString returns the string form of u or an empty string if u is nil.
+func URLString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
+
+// DeveloperKey returns the identifier of d to compare developers of different results.
+// The ID is preferred, because it survives renames.
+func DeveloperKey(d trending.Developer) string {
+	if d.ID > 0 {
+		return strconv.Itoa(d.ID)
+	}
+	return "login:" + strings.ToLower(d.DisplayName)
+}
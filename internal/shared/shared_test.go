@@ -0,0 +1,26 @@
+package shared
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{s: "trending", n: 10, want: "trending"},
+		{s: "trending", n: 8, want: "trending"},
+		{s: "trending", n: 5, want: "tren…"},
+		{s: "äöüß", n: 2, want: "ä…"},
+		{s: "trending", n: 1, want: "…"},
+		{s: "trending", n: 0, want: ""},
+		{s: "trending", n: -1, want: ""},
+		{s: "", n: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := Truncate(tt.s, tt.n); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
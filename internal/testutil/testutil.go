@@ -0,0 +1,77 @@
+// Package testutil contains the test helpers of the golden file tests of the chat, feed and format packages.
+//
+// The results are parsed from the HTML fixtures of the testdata folder of the repository
+// and the output is compared with golden files in the testdata folder of the package under test.
+// After intended changes of the output, the golden files are rewritten with: go test ./<package> -update
+package testutil
+
+import (
+	"bytes"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// update rewrites the golden files with the current output.
+var update = flag.Bool("update", false, "update golden files")
+
+// fixtureDir is the testdata folder of the repository with the HTML fixtures.
+var fixtureDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "testdata")
+}()
+
+// FixtureTransport answers every request with the matching HTML fixture of the trending pages.
+// Using a transport instead of a test server keeps the base URL (and with this all URLs) stable for the golden files.
+type FixtureTransport struct{}
+
+func (FixtureTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	file := "github.com_trending.html"
+	if strings.HasSuffix(r.URL.Path, "/developers") {
+		file = "github.com_trending_developers.html"
+	}
+
+	f, err := os.Open(filepath.Join(fixtureDir, file))
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+		Body:       f,
+		Request:    r,
+	}, nil
+}
+
+// FixtureClient returns a trending client that is served by the HTML fixtures.
+func FixtureClient() *trending.Trending {
+	return trending.NewTrendingWithClient(&http.Client{Transport: FixtureTransport{}})
+}
+
+// Golden compares got with the golden file testdata/name.golden of the package under test.
+// With -update, the golden file is rewritten with got first.
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+
+	golden := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatalf("Writing golden file %s failed: %v", golden, err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Reading golden file %s failed: %v", golden, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Output does not match golden file %s\ngot:\n%s\nwant:\n%s", golden, got, want)
+	}
+}
@@ -0,0 +1,201 @@
+package trending
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// SchemaVersion is the version of the JSON representation of Project, Developer, Language and SpokenLanguage.
+// It is written into every encoded object as "schema_version".
+// The number will be increased if the JSON representation changes in an incompatible way.
+// Objects without a "schema_version" are treated as the current version.
+const SchemaVersion = 1
+
+// MarshalJSON encodes a Project as JSON.
+// URL and ContributorURL are written as plain strings like "https://github.com/andygrunwald/go-trending".
+func (p Project) MarshalJSON() ([]byte, error) {
+	type project Project
+	return json.Marshal(struct {
+		SchemaVersion int `json:"schema_version"`
+		project
+		URL            string `json:"url,omitempty"`
+		ContributorURL string `json:"contributor_url,omitempty"`
+	}{
+		SchemaVersion:  SchemaVersion,
+		project:        project(p),
+		URL:            urlToString(p.URL),
+		ContributorURL: urlToString(p.ContributorURL),
+	})
+}
+
+// UnmarshalJSON decodes a Project from the JSON representation written by MarshalJSON.
+func (p *Project) UnmarshalJSON(data []byte) error {
+	type project Project
+	aux := struct {
+		SchemaVersion int `json:"schema_version"`
+		*project
+		URL            string `json:"url"`
+		ContributorURL string `json:"contributor_url"`
+	}{
+		project: (*project)(p),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(aux.SchemaVersion); err != nil {
+		return err
+	}
+
+	var err error
+	if p.URL, err = stringToURL(aux.URL); err != nil {
+		return err
+	}
+	if p.ContributorURL, err = stringToURL(aux.ContributorURL); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON encodes a Developer as JSON.
+// URL and Avatar are written as plain strings like "https://github.com/torvalds".
+func (d Developer) MarshalJSON() ([]byte, error) {
+	type developer Developer
+	return json.Marshal(struct {
+		SchemaVersion int `json:"schema_version"`
+		developer
+		URL    string `json:"url,omitempty"`
+		Avatar string `json:"avatar,omitempty"`
+	}{
+		SchemaVersion: SchemaVersion,
+		developer:     developer(d),
+		URL:           urlToString(d.URL),
+		Avatar:        urlToString(d.Avatar),
+	})
+}
+
+// UnmarshalJSON decodes a Developer from the JSON representation written by MarshalJSON.
+func (d *Developer) UnmarshalJSON(data []byte) error {
+	type developer Developer
+	aux := struct {
+		SchemaVersion int `json:"schema_version"`
+		*developer
+		URL    string `json:"url"`
+		Avatar string `json:"avatar"`
+	}{
+		developer: (*developer)(d),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(aux.SchemaVersion); err != nil {
+		return err
+	}
+
+	var err error
+	if d.URL, err = stringToURL(aux.URL); err != nil {
+		return err
+	}
+	if d.Avatar, err = stringToURL(aux.Avatar); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON encodes a Language as JSON.
+// URL is written as plain string like "https://github.com/trending/go".
+func (l Language) MarshalJSON() ([]byte, error) {
+	type language Language
+	return json.Marshal(struct {
+		SchemaVersion int `json:"schema_version"`
+		language
+		URL string `json:"url,omitempty"`
+	}{
+		SchemaVersion: SchemaVersion,
+		language:      language(l),
+		URL:           urlToString(l.URL),
+	})
+}
+
+// UnmarshalJSON decodes a Language from the JSON representation written by MarshalJSON.
+func (l *Language) UnmarshalJSON(data []byte) error {
+	type language Language
+	aux := struct {
+		SchemaVersion int `json:"schema_version"`
+		*language
+		URL string `json:"url"`
+	}{
+		language: (*language)(l),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(aux.SchemaVersion); err != nil {
+		return err
+	}
+
+	var err error
+	l.URL, err = stringToURL(aux.URL)
+	return err
+}
+
+// MarshalJSON encodes a SpokenLanguage as JSON.
+// URL is written as plain string like "https://github.com/trending?spoken_language_code=en".
+func (l SpokenLanguage) MarshalJSON() ([]byte, error) {
+	type spokenLanguage SpokenLanguage
+	return json.Marshal(struct {
+		SchemaVersion int `json:"schema_version"`
+		spokenLanguage
+		URL string `json:"url,omitempty"`
+	}{
+		SchemaVersion:  SchemaVersion,
+		spokenLanguage: spokenLanguage(l),
+		URL:            urlToString(l.URL),
+	})
+}
+
+// UnmarshalJSON decodes a SpokenLanguage from the JSON representation written by MarshalJSON.
+func (l *SpokenLanguage) UnmarshalJSON(data []byte) error {
+	type spokenLanguage SpokenLanguage
+	aux := struct {
+		SchemaVersion int `json:"schema_version"`
+		*spokenLanguage
+		URL string `json:"url"`
+	}{
+		spokenLanguage: (*spokenLanguage)(l),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(aux.SchemaVersion); err != nil {
+		return err
+	}
+
+	var err error
+	l.URL, err = stringToURL(aux.URL)
+	return err
+}
+
+// checkSchemaVersion returns an error if version is newer than the SchemaVersion known by this package.
+func checkSchemaVersion(version int) error {
+	if version > SchemaVersion {
+		return fmt.Errorf("trending: unsupported schema version %d (supported up to %d)", version, SchemaVersion)
+	}
+	return nil
+}
+
+// urlToString returns the string form of u or an empty string if u is nil
+func urlToString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
+
+// stringToURL parses s into a url.URL. An empty string results in nil.
+func stringToURL(s string) (*url.URL, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return url.Parse(s)
+}
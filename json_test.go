@@ -0,0 +1,156 @@
+package trending
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProject_JSONRoundTrip(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	projects, err := client.GetProjects(TimeToday, "")
+	if err != nil {
+		t.Errorf("GetProjects returned error: %v", err)
+	}
+
+	b, err := json.Marshal(projects)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var got []Project
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, projects) {
+		t.Errorf("JSON round trip returned %+v, want %+v", got, projects)
+	}
+}
+
+func TestDeveloper_JSONRoundTrip(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending/developers", func(w http.ResponseWriter, r *http.Request) {
+		website := getContentOfFile("./testdata/github.com_trending_developers.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	developers, err := client.GetDevelopers(TimeToday, "")
+	if err != nil {
+		t.Errorf("GetDevelopers returned error: %v", err)
+	}
+
+	b, err := json.Marshal(developers)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var got []Developer
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, developers) {
+		t.Errorf("JSON round trip returned %+v, want %+v", got, developers)
+	}
+}
+
+func TestLanguage_JSONRoundTrip(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	languages, err := client.GetLanguages()
+	if err != nil {
+		t.Errorf("GetLanguages returned error: %v", err)
+	}
+
+	b, err := json.Marshal(languages)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var got []Language
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, languages) {
+		t.Errorf("JSON round trip returned %+v, want %+v", got, languages)
+	}
+}
+
+func TestDeveloper_MarshalJSON(t *testing.T) {
+	d := Developer{
+		ID:          1024025,
+		DisplayName: "torvalds",
+		FullName:    "Linus Torvalds",
+		URL:         mustParseURL("https://github.com/torvalds"),
+		Avatar:      mustParseURL("https://avatars.githubusercontent.com/u/1024025?v=4"),
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	want := `{"schema_version":1,"id":1024025,"display_name":"torvalds","full_name":"Linus Torvalds","url":"https://github.com/torvalds","avatar":"https://avatars.githubusercontent.com/u/1024025?v=4"}`
+	if got := string(b); got != want {
+		t.Errorf("json.Marshal returned %s, want %s", got, want)
+	}
+}
+
+func TestProject_UnmarshalJSON_WithoutSchemaVersion(t *testing.T) {
+	data := `{"name":"andygrunwald/go-trending","url":"https://github.com/andygrunwald/go-trending","contributors":[{"display_name":"andygrunwald"}]}`
+
+	var p Project
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	if p.URL == nil || p.URL.String() != "https://github.com/andygrunwald/go-trending" {
+		t.Errorf("Project.URL is %v, want %s", p.URL, "https://github.com/andygrunwald/go-trending")
+	}
+	if p.ContributorURL != nil {
+		t.Errorf("Project.ContributorURL is %v, want nil", p.ContributorURL)
+	}
+	if len(p.Contributor) != 1 || p.Contributor[0].DisplayName != "andygrunwald" {
+		t.Errorf("Project.Contributor is %+v, want one contributor \"andygrunwald\"", p.Contributor)
+	}
+}
+
+func TestLanguage_UnmarshalJSON_UnsupportedSchemaVersion(t *testing.T) {
+	data := fmt.Sprintf(`{"schema_version":%d,"name":"Go"}`, SchemaVersion+1)
+
+	var l Language
+	err := json.Unmarshal([]byte(data), &l)
+	if err == nil || !strings.Contains(err.Error(), "unsupported schema version") {
+		t.Errorf("json.Unmarshal returned error %v, want unsupported schema version error", err)
+	}
+}
+
+// mustParseURL is a utility function to parse s into a url.URL. It panics on error.
+func mustParseURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
@@ -0,0 +1,14 @@
+package trending
+
+import (
+	"context"
+	"log/slog"
+)
+
+// log writes msg with args to t.Logger, if set.
+func (t *Trending) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if t.Logger == nil {
+		return
+	}
+	t.Logger.Log(ctx, level, msg, args...)
+}
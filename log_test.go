@@ -0,0 +1,121 @@
+package trending
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestLogger returns a logger writing all levels as text into the returned buffer.
+func newTestLogger() (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// Drop time and durations to get stable output
+			if a.Key == slog.TimeKey || a.Key == "duration" || a.Key == "fetched_at" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	return slog.New(handler), &buf
+}
+
+func TestTrending_Logger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+	mux.HandleFunc("/trending/developers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+	})
+
+	logger, buf := newTestLogger()
+	client.Logger = logger
+	client.Cache = NewMemoryCache(time.Minute)
+
+	client.GetProjects(TimeToday, "")
+	client.GetProjects(TimeToday, "")
+	client.GetDevelopers(TimeToday, "")
+
+	want := []string{
+		fmt.Sprintf(`level=DEBUG msg="requesting page" page=projects url="%s/trending?since=daily"`, server.URL),
+		fmt.Sprintf(`level=DEBUG msg="received response" page=projects url="%s/trending?since=daily" status=200`, server.URL),
+		`level=DEBUG msg="parsed page" page=projects items=25`,
+		fmt.Sprintf(`level=DEBUG msg="serving page from cache" page=projects url="%s/trending?since=daily"`, server.URL),
+		`level=DEBUG msg="parsed page" page=projects items=25`,
+		fmt.Sprintf(`level=DEBUG msg="requesting page" page=developers url="%s/trending/developers?since=daily"`, server.URL),
+		fmt.Sprintf(`level=DEBUG msg="received response" page=developers url="%s/trending/developers?since=daily" status=429`, server.URL),
+		fmt.Sprintf(`level=WARN msg="unexpected status code" page=developers url="%s/trending/developers?since=daily" status=429 retry_after=30`, server.URL),
+	}
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("Logger received %d lines, want %d:\n%s", len(got), len(want), buf.String())
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Log line %d is\n%s\nwant\n%s", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestTrending_LoggerLayoutDrift(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><div class="Box"><article class="Box-row"><h2><a href="/broken">broken</a></h2></article></div></body></html>`)
+	})
+
+	logger, buf := newTestLogger()
+	client.Logger = logger
+
+	projects, err := client.GetProjects(TimeToday, "")
+	if err != nil {
+		t.Fatalf("GetProjects returned error: %v", err)
+	}
+	if len(projects) != 0 {
+		t.Errorf("GetProjects returned %d projects, want 0", len(projects))
+	}
+
+	for _, want := range []string{
+		`level=WARN msg="skipping project with invalid name, the layout of GitHub may have changed" page=projects`,
+		`error="invalid name \"broken\" of project 1"`,
+		`level=WARN msg="page contains no items, the layout of GitHub may have changed" page=projects`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Log does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestTrending_LoggerDefaultSilent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html></html>`)
+	})
+
+	// Without a Logger nothing must be logged, not even through the default logger of slog
+	var buf bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(defaultLogger)
+
+	if _, err := client.GetProjects(TimeToday, ""); err != nil {
+		t.Fatalf("GetProjects returned error: %v", err)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("Trending without Logger logged %q, want nothing", buf.String())
+	}
+}
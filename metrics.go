@@ -0,0 +1,60 @@
+package trending
+
+import (
+	"time"
+)
+
+// Pages of GitHub as reported to Metrics.
+const (
+	// PageProjects is the page of trending repositories.
+	PageProjects = "projects"
+	// PageDevelopers is the page of trending developers.
+	PageDevelopers = "developers"
+	// PageLanguages is the page with the programing languages available for filtering.
+	PageLanguages = "languages"
+	// PageSpokenLanguages is the page with the spoken languages available for filtering.
+	PageSpokenLanguages = "spoken_languages"
+)
+
+// Metrics receives measurements of fetching and parsing the pages of GitHub.
+// Set Trending.Metrics to collect them. The module github.com/andygrunwald/go-trending/metrics
+// provides a Prometheus implementation.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// RequestDone is called after page was requested from GitHub.
+	// status is the HTTP status code of the response or 0 if no response was received.
+	RequestDone(page string, status int, duration time.Duration)
+
+	// CacheLookup is called when page was looked up in the Cache of Trending.
+	CacheLookup(page string, hit bool)
+
+	// PageParsed is called after page was parsed successfully with the number of items found.
+	PageParsed(page string, items int, duration time.Duration)
+
+	// ParseFailed is called if page or a single item of it could not be parsed.
+	ParseFailed(page string, err error)
+
+	// ProjectsFetched is called with the trending projects of q after they were parsed.
+	ProjectsFetched(q Query, projects []Project)
+}
+
+// requestDone reports a request of page to t.Metrics, if set.
+func (t *Trending) requestDone(page string, status int, start time.Time) {
+	if t.Metrics != nil {
+		t.Metrics.RequestDone(page, status, time.Since(start))
+	}
+}
+
+// cacheLookup reports a cache lookup of page to t.Metrics, if set.
+func (t *Trending) cacheLookup(page string, hit bool) {
+	if t.Metrics != nil {
+		t.Metrics.CacheLookup(page, hit)
+	}
+}
+
+// parseFailed reports a parse failure of page to t.Metrics, if set.
+func (t *Trending) parseFailed(page string, err error) {
+	if t.Metrics != nil {
+		t.Metrics.ParseFailed(page, err)
+	}
+}
@@ -0,0 +1,182 @@
+// Package metrics provides a Prometheus implementation of trending.Metrics.
+//
+//	m := metrics.NewPrometheus(metrics.Options{ProjectStars: true})
+//	trend := trending.NewTrending()
+//	trend.Metrics = m
+//	http.Handle("/metrics", m.Handler())
+//
+// The exposed metrics are (with the default namespace "trending"):
+//
+//	trending_requests_total{page,status}                   requests sent to GitHub, status is "error" if no response was received
+//	trending_request_duration_seconds{page}                latency of the requests sent to GitHub
+//	trending_parse_duration_seconds{page}                  time to parse a page
+//	trending_parse_failures_total{page}                    pages or items that could not be parsed
+//	trending_page_items{page}                              number of items found on the last parsed page
+//	trending_cache_lookups_total{page,result}              cache lookups, result is "hit" or "miss"
+//	trending_project_stars{since,language,spoken_language,repository}  stars of the period per trending repository (optional)
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// DefaultNamespace is the default prefix of all metric names.
+const DefaultNamespace = "trending"
+
+// Options configure a Prometheus.
+type Options struct {
+	// Namespace is the prefix of all metric names. Defaults to DefaultNamespace.
+	Namespace string
+
+	// Registry is the registry the metrics are registered at and Handler exposes.
+	// Defaults to a new registry including the Go runtime and process metrics.
+	Registry *prometheus.Registry
+
+	// ProjectStars enables the gauge with the stars of the period per trending repository.
+	// It creates one series per repository and query, so it is disabled by default.
+	ProjectStars bool
+}
+
+// Prometheus collects the measurements of a trending.Trending as Prometheus metrics.
+// Use NewPrometheus to create one.
+type Prometheus struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	parseDuration   *prometheus.HistogramVec
+	parseFailures   *prometheus.CounterVec
+	pageItems       *prometheus.GaugeVec
+	cacheLookups    *prometheus.CounterVec
+
+	// projectStars is nil unless Options.ProjectStars is set.
+	projectStars *prometheus.GaugeVec
+}
+
+// NewPrometheus creates the metrics and registers them at the registry of opts.
+// It panics if the metrics are already registered there.
+func NewPrometheus(opts Options) *Prometheus {
+	if len(opts.Namespace) == 0 {
+		opts.Namespace = DefaultNamespace
+	}
+	if opts.Registry == nil {
+		opts.Registry = prometheus.NewRegistry()
+		opts.Registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		)
+	}
+
+	p := &Prometheus{
+		registry: opts.Registry,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace,
+			Name:      "requests_total",
+			Help:      "Requests sent to GitHub by page and HTTP status code.",
+		}, []string{"page", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: opts.Namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the requests sent to GitHub by page.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"page"}),
+		parseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: opts.Namespace,
+			Name:      "parse_duration_seconds",
+			Help:      "Time to parse a page by page.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 12),
+		}, []string{"page"}),
+		parseFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace,
+			Name:      "parse_failures_total",
+			Help:      "Pages or items of pages that could not be parsed.",
+		}, []string{"page"}),
+		pageItems: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: opts.Namespace,
+			Name:      "page_items",
+			Help:      "Number of items found on the last parsed page.",
+		}, []string{"page"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Cache lookups of pages by result (hit or miss).",
+		}, []string{"page", "result"}),
+	}
+	p.registry.MustRegister(p.requests, p.requestDuration, p.parseDuration, p.parseFailures, p.pageItems, p.cacheLookups)
+
+	if opts.ProjectStars {
+		p.projectStars = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: opts.Namespace,
+			Name:      "project_stars",
+			Help:      "Stars received in the period by trending repository.",
+		}, []string{"since", "language", "spoken_language", "repository"})
+		p.registry.MustRegister(p.projectStars)
+	}
+	return p
+}
+
+// Handler returns an http.Handler exposing the metrics in the Prometheus exposition format.
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{Registry: p.registry})
+}
+
+// RequestDone implements trending.Metrics.
+func (p *Prometheus) RequestDone(page string, status int, duration time.Duration) {
+	label := "error"
+	if status > 0 {
+		label = strconv.Itoa(status)
+	}
+	p.requests.WithLabelValues(page, label).Inc()
+	p.requestDuration.WithLabelValues(page).Observe(duration.Seconds())
+}
+
+// CacheLookup implements trending.Metrics.
+func (p *Prometheus) CacheLookup(page string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	p.cacheLookups.WithLabelValues(page, result).Inc()
+}
+
+// PageParsed implements trending.Metrics.
+func (p *Prometheus) PageParsed(page string, items int, duration time.Duration) {
+	p.pageItems.WithLabelValues(page).Set(float64(items))
+	p.parseDuration.WithLabelValues(page).Observe(duration.Seconds())
+}
+
+// ParseFailed implements trending.Metrics.
+func (p *Prometheus) ParseFailed(page string, err error) {
+	p.parseFailures.WithLabelValues(page).Inc()
+}
+
+// ProjectsFetched implements trending.Metrics.
+// With Options.ProjectStars, the stars gauge of q is replaced by the stars of the period of projects (trending.Project.PeriodStars).
+// Repositories that left the trending page of q are removed.
+func (p *Prometheus) ProjectsFetched(q trending.Query, projects []trending.Project) {
+	if p.projectStars == nil {
+		return
+	}
+
+	since := q.Since
+	if len(since) == 0 {
+		since = trending.TimeToday
+	}
+	labels := prometheus.Labels{
+		"since":           since,
+		"language":        q.Language,
+		"spoken_language": q.SpokenLanguage,
+	}
+	p.projectStars.DeletePartialMatch(labels)
+	for _, project := range projects {
+		p.projectStars.WithLabelValues(since, q.Language, q.SpokenLanguage, project.Name).Set(float64(project.PeriodStars))
+	}
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// scrape returns the exposition of p.
+func scrape(t *testing.T, p *Prometheus) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	p.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Handler returned status %d, want %d", rec.Code, http.StatusOK)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+// testContains checks that the exposition contains all lines of want.
+func testContains(t *testing.T, exposition string, want ...string) {
+	t.Helper()
+
+	for _, line := range want {
+		if !strings.Contains(exposition, line+"\n") {
+			t.Errorf("Exposition does not contain %q:\n%s", line, exposition)
+		}
+	}
+}
+
+func TestPrometheus(t *testing.T) {
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/developers") {
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
+		http.ServeFile(w, r, "../testdata/github.com_trending.html")
+	}))
+	defer github.Close()
+
+	p := NewPrometheus(Options{Registry: prometheus.NewRegistry()})
+	client := trending.NewTrending()
+	client.BaseURL, _ = url.Parse(github.URL)
+	client.Cache = trending.NewMemoryCache(time.Minute)
+	client.Metrics = p
+
+	client.GetProjects(trending.TimeWeek, "go")
+	client.GetProjects(trending.TimeWeek, "go")
+	client.GetDevelopers(trending.TimeWeek, "go")
+	client.GetLanguages()
+	p.ParseFailed(trending.PageProjects, errors.New("stars of a/b: invalid syntax"))
+
+	exposition := scrape(t, p)
+	testContains(t, exposition,
+		`trending_requests_total{page="projects",status="200"} 1`,
+		`trending_requests_total{page="developers",status="429"} 1`,
+		`trending_requests_total{page="languages",status="200"} 1`,
+		`trending_request_duration_seconds_count{page="projects"} 1`,
+		`trending_parse_duration_seconds_count{page="projects"} 2`,
+		`trending_parse_failures_total{page="projects"} 1`,
+		`trending_page_items{page="projects"} 25`,
+		`trending_cache_lookups_total{page="projects",result="hit"} 1`,
+		`trending_cache_lookups_total{page="projects",result="miss"} 1`,
+		`trending_cache_lookups_total{page="developers",result="miss"} 1`,
+	)
+	if strings.Contains(exposition, "trending_project_stars") {
+		t.Error("Exposition contains trending_project_stars, although Options.ProjectStars is disabled")
+	}
+}
+
+func TestPrometheus_ProjectStars(t *testing.T) {
+	p := NewPrometheus(Options{Namespace: "gh", Registry: prometheus.NewRegistry(), ProjectStars: true})
+
+	q := trending.Query{Language: "go"}
+	p.ProjectsFetched(q, []trending.Project{{Name: "a/old", Stars: 9000, PeriodStars: 10}, {Name: "b/stays", Stars: 9000, PeriodStars: 20}})
+	p.ProjectsFetched(trending.Query{Since: trending.TimeWeek}, []trending.Project{{Name: "c/weekly", Stars: 9000, PeriodStars: 30}})
+	p.ProjectsFetched(q, []trending.Project{{Name: "b/stays", Stars: 9000, PeriodStars: 25}})
+
+	exposition := scrape(t, p)
+	testContains(t, exposition,
+		`gh_project_stars{language="go",repository="b/stays",since="daily",spoken_language=""} 25`,
+		`gh_project_stars{language="",repository="c/weekly",since="weekly",spoken_language=""} 30`,
+	)
+	if strings.Contains(exposition, "a/old") {
+		t.Errorf("Exposition contains repository a/old, which left the trending page:\n%s", exposition)
+	}
+}
+
+func TestPrometheus_DefaultRegistry(t *testing.T) {
+	p := NewPrometheus(Options{})
+
+	testContains(t, scrape(t, p), "# TYPE go_goroutines gauge")
+}
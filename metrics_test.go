@@ -0,0 +1,90 @@
+package trending
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics is a Metrics implementation recording all calls as strings.
+type recordingMetrics struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (m *recordingMetrics) record(format string, a ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, fmt.Sprintf(format, a...))
+}
+
+func (m *recordingMetrics) RequestDone(page string, status int, duration time.Duration) {
+	m.record("request %s %d", page, status)
+}
+
+func (m *recordingMetrics) CacheLookup(page string, hit bool) {
+	m.record("cache %s %v", page, hit)
+}
+
+func (m *recordingMetrics) PageParsed(page string, items int, duration time.Duration) {
+	m.record("parsed %s %d", page, items)
+}
+
+func (m *recordingMetrics) ParseFailed(page string, err error) {
+	m.record("failed %s %v", page, err)
+}
+
+func (m *recordingMetrics) ProjectsFetched(q Query, projects []Project) {
+	m.record("projects %s %d", q.Since, len(projects))
+}
+
+func TestTrending_Metrics(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+	mux.HandleFunc("/trending/developers", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	})
+
+	m := &recordingMetrics{}
+	client.Metrics = m
+	client.Cache = NewMemoryCache(time.Minute)
+
+	if _, err := client.GetProjects(TimeWeek, ""); err != nil {
+		t.Fatalf("GetProjects returned error: %v", err)
+	}
+	if _, err := client.GetProjects(TimeWeek, ""); err != nil {
+		t.Fatalf("GetProjects returned error: %v", err)
+	}
+	if _, err := client.GetDevelopers(TimeWeek, ""); err == nil {
+		t.Fatal("GetDevelopers returned no error, want a status error")
+	}
+	if _, err := client.GetSpokenLanguages(); err != nil {
+		t.Fatalf("GetSpokenLanguages returned error: %v", err)
+	}
+
+	want := []string{
+		"cache projects false",
+		"request projects 200",
+		"parsed projects 25",
+		"projects weekly 25",
+		"cache projects true",
+		"parsed projects 25",
+		"projects weekly 25",
+		"cache developers false",
+		"request developers 503",
+		"cache spoken_languages false",
+		"request spoken_languages 200",
+		"parsed spoken_languages 184",
+	}
+	if !reflect.DeepEqual(m.calls, want) {
+		t.Errorf("Metrics received %v, want %v", m.calls, want)
+	}
+}
@@ -0,0 +1,336 @@
+// Package notify delivers trending change events to webhooks.
+//
+// Every event is POSTed as JSON to all configured webhooks.
+// Projects and developers use their regular JSON form (see trending.SchemaVersion):
+//
+//	n := notify.New([]notify.Webhook{
+//		{URL: "https://hooks.example.com/trending", Secret: "s3cr3t"},
+//	}, notify.Options{DeadLetterFile: "failed-deliveries.ndjson"})
+//
+//	err := watcher.Run(ctx, n.Handler(ctx))
+//
+// If a secret is configured, the body is signed with HMAC-SHA256 and the signature is
+// sent in the SignatureHeader as "sha256=<hex>". Receivers can check it with Verify.
+//
+// Handler delivers in the goroutine of the caller. A Queue delivers in the background instead,
+// so that a slow or unreachable webhook doesn't stall the watcher.
+//
+// Failed deliveries are retried with an exponential backoff.
+// Deliveries that fail after all retries are appended to the dead-letter file, one JSON object per line.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygrunwald/go-trending/watch"
+)
+
+// Headers sent with every delivery.
+const (
+	// SignatureHeader is the HMAC-SHA256 signature of the body as "sha256=<hex>", if a secret is configured.
+	SignatureHeader = "X-Trending-Signature-256"
+	// EventHeader is the type of the payload like "entered" or "left".
+	EventHeader = "X-Trending-Event"
+	// DeliveryHeader is the unique ID of the delivery. It stays the same for all retries.
+	DeliveryHeader = "X-Trending-Delivery"
+)
+
+// Defaults of Options.
+const (
+	// DefaultMaxRetries is the number of retries after the first failed attempt.
+	DefaultMaxRetries = 3
+	// DefaultBackoff is the wait time before the first retry. It doubles with every retry.
+	DefaultBackoff = time.Second
+	// DefaultTimeout is the timeout of a single delivery attempt.
+	DefaultTimeout = 10 * time.Second
+)
+
+// Webhook is a receiver of notifications.
+type Webhook struct {
+	// URL is the address the payloads are POSTed to.
+	URL string
+
+	// Secret is the key to sign the payloads with. If empty, payloads are not signed.
+	Secret string
+}
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	// ID is the unique ID of the delivery, the same as in the DeliveryHeader.
+	ID string `json:"id"`
+
+	// Type is the type of the payload, the same as in the EventHeader.
+	Type string `json:"type"`
+
+	// SentAt is the time the payload was created.
+	SentAt time.Time `json:"sent_at"`
+
+	// Event is the trending change.
+	Event any `json:"event"`
+}
+
+// Options configure a Notifier.
+type Options struct {
+	// Client is the HTTP client used for deliveries. Defaults to a client with DefaultTimeout.
+	Client *http.Client
+
+	// MaxRetries is the number of retries after the first failed attempt.
+	// Defaults to DefaultMaxRetries. A negative value disables retries.
+	MaxRetries int
+
+	// Backoff is the wait time before the first retry. It doubles with every retry.
+	// Defaults to DefaultBackoff.
+	Backoff time.Duration
+
+	// DeadLetterFile is the file that failed deliveries are appended to.
+	// If empty, failed deliveries are dropped.
+	DeadLetterFile string
+
+	// ErrorHandler is called by Handler and Queue if a delivery failed. If nil, errors are ignored.
+	// For a Queue, it is called from the goroutine of the queue.
+	ErrorHandler func(err error)
+}
+
+// Notifier delivers payloads to webhooks.
+type Notifier struct {
+	webhooks []Webhook
+	opts     Options
+
+	// mu protects writes to the dead-letter file
+	mu sync.Mutex
+}
+
+// New returns a Notifier that delivers to webhooks.
+func New(webhooks []Webhook, opts Options) *Notifier {
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultMaxRetries
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultBackoff
+	}
+
+	return &Notifier{
+		webhooks: webhooks,
+		opts:     opts,
+	}
+}
+
+// Handler returns a function that notifies about every event.
+// It can be passed to watch.Watcher.Run. Errors are reported to Options.ErrorHandler.
+func (n *Notifier) Handler(ctx context.Context) func(watch.Event) {
+	return func(e watch.Event) {
+		n.report(n.Notify(ctx, e))
+	}
+}
+
+// report passes err to Options.ErrorHandler, if both are set.
+func (n *Notifier) report(err error) {
+	if err != nil && n.opts.ErrorHandler != nil {
+		n.opts.ErrorHandler(err)
+	}
+}
+
+// Notify delivers the event e to all webhooks.
+func (n *Notifier) Notify(ctx context.Context, e watch.Event) error {
+	return n.Send(ctx, string(e.Type), e)
+}
+
+// Send delivers event as payload of type typ to all webhooks.
+// All webhooks are tried, even if one fails. The returned error joins the errors of all failed webhooks.
+func (n *Notifier) Send(ctx context.Context, typ string, event any) error {
+	payload, body, err := newPayload(typ, event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, wh := range n.webhooks {
+		if err := n.deliver(ctx, wh, payload, body); err != nil {
+			errs = append(errs, err)
+			if dlErr := n.deadLetter(wh, payload, err); dlErr != nil {
+				errs = append(errs, dlErr)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newPayload returns the payload of type typ with event and its JSON encoding.
+func newPayload(typ string, event any) (Payload, []byte, error) {
+	id, err := newDeliveryID()
+	if err != nil {
+		return Payload{}, nil, err
+	}
+
+	payload := Payload{
+		ID:     id,
+		Type:   typ,
+		SentAt: time.Now().UTC(),
+		Event:  event,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Payload{}, nil, err
+	}
+	return payload, body, nil
+}
+
+// deliver POSTs body to wh and retries on failures.
+func (n *Notifier) deliver(ctx context.Context, wh Webhook, payload Payload, body []byte) error {
+	backoff := n.opts.Backoff
+
+	var err error
+	for attempt := 0; attempt <= n.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("notify: delivery %s to %s canceled: %w", payload.ID, wh.URL, ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var retry bool
+		retry, err = n.post(ctx, wh, payload, body)
+		if err == nil || !retry {
+			break
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("notify: delivery %s to %s failed: %w", payload.ID, wh.URL, err)
+	}
+	return nil
+}
+
+// post executes a single delivery attempt. It reports whether a failure is worth a retry.
+// Network errors, 429 and 5xx responses are retried, other responses are final.
+func (n *Notifier) post(ctx context.Context, wh Webhook, payload Payload, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-trending")
+	req.Header.Set(EventHeader, payload.Type)
+	req.Header.Set(DeliveryHeader, payload.ID)
+	if len(wh.Secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(wh.Secret, body))
+	}
+
+	res, err := n.opts.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return false, nil
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status %s", res.Status)
+	default:
+		return false, fmt.Errorf("unexpected status %s", res.Status)
+	}
+}
+
+// deadLetter is a failed delivery as written into the dead-letter file.
+type deadLetter struct {
+	URL      string    `json:"url"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+	Payload  Payload   `json:"payload"`
+}
+
+// deadLetterAll appends the undelivered event of type typ for all webhooks into the dead-letter file.
+func (n *Notifier) deadLetterAll(typ string, event any, deliveryErr error) error {
+	if len(n.opts.DeadLetterFile) == 0 {
+		return nil
+	}
+	payload, _, err := newPayload(typ, event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, wh := range n.webhooks {
+		errs = append(errs, n.deadLetter(wh, payload, deliveryErr))
+	}
+	return errors.Join(errs...)
+}
+
+// deadLetter appends the failed delivery of payload to wh into the dead-letter file.
+func (n *Notifier) deadLetter(wh Webhook, payload Payload, deliveryErr error) error {
+	if len(n.opts.DeadLetterFile) == 0 {
+		return nil
+	}
+
+	line, err := json.Marshal(deadLetter{
+		URL:      wh.URL,
+		Error:    deliveryErr.Error(),
+		FailedAt: time.Now().UTC(),
+		Payload:  payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.opts.DeadLetterFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("notify: writing dead letter: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("notify: writing dead letter: %w", err)
+	}
+	return f.Close()
+}
+
+// Sign returns the HMAC-SHA256 signature of body with secret as "sha256=<hex>".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is a valid signature of body with secret.
+// signature is the value of the SignatureHeader.
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// newDeliveryID returns a random ID for a delivery.
+func newDeliveryID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
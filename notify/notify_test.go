@@ -0,0 +1,209 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/watch"
+)
+
+// delivery is a single request received by a receiver.
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver is a webhook receiver for tests.
+// It answers the requests with the status codes of statuses, one after the other. Afterwards it answers 200.
+type receiver struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	statuses   []int
+	deliveries []delivery
+}
+
+// newReceiver starts a receiver answering with statuses.
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	t.Helper()
+
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.deliveries = append(r.deliveries, delivery{header: req.Header, body: body})
+
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// received returns all deliveries so far.
+func (r *receiver) received() []delivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]delivery(nil), r.deliveries...)
+}
+
+// testEvent returns an Entered event for the project andygrunwald/go-trending.
+func testEvent() watch.Event {
+	u, _ := url.Parse("https://github.com/andygrunwald/go-trending")
+	return watch.Event{
+		Type:    watch.Entered,
+		Kind:    watch.KindProjects,
+		Query:   trending.Query{Since: trending.TimeToday, Language: "go"},
+		Time:    time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC),
+		Project: &trending.Project{Name: "andygrunwald/go-trending", Owner: "andygrunwald", RepositoryName: "go-trending", Stars: 42, URL: u},
+		NewRank: 3,
+	}
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	r := newReceiver(t)
+	n := New([]Webhook{{URL: r.URL, Secret: "s3cr3t"}}, Options{})
+
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	deliveries := r.received()
+	if len(deliveries) != 1 {
+		t.Fatalf("Receiver got %d deliveries, want 1", len(deliveries))
+	}
+	d := deliveries[0]
+
+	if !Verify("s3cr3t", d.body, d.header.Get(SignatureHeader)) {
+		t.Errorf("Signature %q is invalid", d.header.Get(SignatureHeader))
+	}
+	if got := d.header.Get(EventHeader); got != "entered" {
+		t.Errorf("Header %s is %q, want %q", EventHeader, got, "entered")
+	}
+	if got := d.header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Header Content-Type is %q, want %q", got, "application/json")
+	}
+
+	var payload struct {
+		ID    string
+		Type  string
+		Event watch.Event
+	}
+	if err := json.Unmarshal(d.body, &payload); err != nil {
+		t.Fatalf("Decoding payload failed: %v", err)
+	}
+	if payload.ID == "" || payload.ID != d.header.Get(DeliveryHeader) {
+		t.Errorf("Payload ID is %q, want %q", payload.ID, d.header.Get(DeliveryHeader))
+	}
+	if p := payload.Event.Project; p == nil || p.Name != "andygrunwald/go-trending" || p.URL.String() != "https://github.com/andygrunwald/go-trending" {
+		t.Errorf("Payload contains project %+v, want andygrunwald/go-trending", p)
+	}
+}
+
+func TestNotifier_Retry(t *testing.T) {
+	r := newReceiver(t, http.StatusInternalServerError, http.StatusTooManyRequests)
+	n := New([]Webhook{{URL: r.URL}}, Options{Backoff: time.Millisecond})
+
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	deliveries := r.received()
+	if len(deliveries) != 3 {
+		t.Fatalf("Receiver got %d deliveries, want 3", len(deliveries))
+	}
+	if deliveries[0].header.Get(DeliveryHeader) != deliveries[2].header.Get(DeliveryHeader) {
+		t.Error("Retries use a different delivery ID")
+	}
+	if deliveries[0].header.Get(SignatureHeader) != "" {
+		t.Error("Delivery without secret is signed")
+	}
+}
+
+func TestNotifier_DeadLetter(t *testing.T) {
+	failing := newReceiver(t, http.StatusBadRequest)
+	working := newReceiver(t)
+	deadLetterFile := filepath.Join(t.TempDir(), "dead-letter.ndjson")
+
+	var handlerErr error
+	n := New([]Webhook{{URL: failing.URL}, {URL: working.URL}}, Options{
+		Backoff:        time.Millisecond,
+		DeadLetterFile: deadLetterFile,
+		ErrorHandler:   func(err error) { handlerErr = err },
+	})
+	n.Handler(context.Background())(testEvent())
+
+	if handlerErr == nil {
+		t.Error("ErrorHandler was not called for a failed delivery")
+	}
+	// 400 is not retried
+	if got := len(failing.received()); got != 1 {
+		t.Errorf("Failing receiver got %d deliveries, want 1", got)
+	}
+	if got := len(working.received()); got != 1 {
+		t.Errorf("Working receiver got %d deliveries, want 1", got)
+	}
+
+	f, err := os.Open(deadLetterFile)
+	if err != nil {
+		t.Fatalf("Opening dead-letter file failed: %v", err)
+	}
+	defer f.Close()
+
+	var lines []deadLetter
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var l deadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			t.Fatalf("Decoding dead letter failed: %v", err)
+		}
+		lines = append(lines, l)
+	}
+
+	if len(lines) != 1 || lines[0].URL != failing.URL || lines[0].Payload.Type != "entered" {
+		t.Errorf("Dead-letter file contains %+v, want one entry for %s", lines, failing.URL)
+	}
+}
+
+func TestNotifier_Canceled(t *testing.T) {
+	r := newReceiver(t, http.StatusServiceUnavailable)
+	n := New([]Webhook{{URL: r.URL}}, Options{Backoff: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if err := n.Notify(ctx, testEvent()); err == nil {
+		t.Error("Notify returned no error after the context was canceled")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"type":"entered"}`)
+	signature := Sign("s3cr3t", body)
+
+	if !Verify("s3cr3t", body, signature) {
+		t.Error("Verify returned false for a valid signature")
+	}
+	if Verify("other", body, signature) {
+		t.Error("Verify returned true for a different secret")
+	}
+	if Verify("s3cr3t", body, signature[len("sha256="):]) {
+		t.Error("Verify returned true for a signature without prefix")
+	}
+}
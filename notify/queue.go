@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/andygrunwald/go-trending/watch"
+)
+
+// DefaultQueueSize is the default number of events a Queue buffers.
+const DefaultQueueSize = 100
+
+// errQueueFull is the delivery error of events dropped by a full Queue.
+var errQueueFull = errors.New("queue full")
+
+// Queue delivers events in a background goroutine, so that slow or unreachable webhooks
+// don't block the caller, like the polling of a watch.Watcher:
+//
+//	q := n.NewQueue(notify.DefaultQueueSize)
+//	defer q.Close(shutdownCtx)
+//	err := watcher.Run(ctx, q.Handler())
+//
+// Events are delivered one after the other in the order they were queued.
+// If the queue is full, new events are dropped, reported to Options.ErrorHandler and written to the dead-letter file.
+type Queue struct {
+	n      *Notifier
+	events chan watch.Event
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewQueue starts a Queue of n that buffers up to size events.
+// If size is zero or negative, DefaultQueueSize is used. The queue has to be closed with Close.
+func (n *Notifier) NewQueue(size int) *Queue {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &Queue{
+		n:      n,
+		events: make(chan watch.Event, size),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go q.run(ctx)
+	return q
+}
+
+// run delivers the queued events until the queue is closed and empty.
+func (q *Queue) run(ctx context.Context) {
+	defer close(q.done)
+	for e := range q.events {
+		q.n.report(q.n.Notify(ctx, e))
+	}
+}
+
+// Handler returns a function that queues every event without blocking.
+// It can be passed to watch.Watcher.Run. Events queued after Close are dropped.
+func (q *Queue) Handler() func(watch.Event) {
+	return q.Enqueue
+}
+
+// Enqueue queues the event e for delivery without blocking.
+func (q *Queue) Enqueue(e watch.Event) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+
+	select {
+	case q.events <- e:
+	default:
+		err := fmt.Errorf("notify: %s event of %s dropped: %w", e.Type, e.Name(), errQueueFull)
+		q.n.report(errors.Join(err, q.n.deadLetterAll(string(e.Type), e, errQueueFull)))
+	}
+}
+
+// Close stops accepting events and waits until all queued events are delivered or ctx is done.
+// If ctx is done first, running deliveries are canceled and the remaining events fail,
+// so they end up in the dead-letter file. Close returns the error of ctx in this case.
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.events)
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-q.done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		<-q.done
+		return ctx.Err()
+	}
+}
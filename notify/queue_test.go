@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueue_Close(t *testing.T) {
+	r := newReceiver(t)
+	q := New([]Webhook{{URL: r.URL}}, Options{}).NewQueue(0)
+
+	handle := q.Handler()
+	for i := 0; i < 3; i++ {
+		handle(testEvent())
+	}
+	if err := q.Close(context.Background()); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	// All queued events are delivered before Close returns, later ones are dropped
+	handle(testEvent())
+	if got := len(r.received()); got != 3 {
+		t.Errorf("Receiver got %d deliveries, want 3", got)
+	}
+}
+
+func TestQueue_DoesNotBlock(t *testing.T) {
+	// The webhook hangs until its request is canceled or the test is done
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	deadLetterFile := filepath.Join(t.TempDir(), "dead-letter.ndjson")
+
+	var mu sync.Mutex
+	var errs []error
+	n := New([]Webhook{{URL: server.URL}}, Options{
+		MaxRetries:     -1,
+		DeadLetterFile: deadLetterFile,
+		ErrorHandler: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	})
+	q := n.NewQueue(1)
+
+	// The first event is delivered, the second waits in the queue and the third is dropped
+	start := time.Now()
+	q.Enqueue(testEvent())
+	<-started
+	q.Enqueue(testEvent())
+	q.Enqueue(testEvent())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Enqueue blocked for %v", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := q.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 3 || !errors.Is(errs[0], errQueueFull) {
+		t.Errorf("ErrorHandler got %v, want the dropped event and 2 failed deliveries", errs)
+	}
+	b, err := os.ReadFile(deadLetterFile)
+	if err != nil {
+		t.Fatalf("Reading dead-letter file failed: %v", err)
+	}
+	if got := strings.Count(string(b), "\n"); got != 3 {
+		t.Errorf("Dead-letter file contains %d entries, want 3", got)
+	}
+}
@@ -0,0 +1,25 @@
+module github.com/andygrunwald/go-trending/otel
+
+go 1.23.0
+
+require (
+	github.com/andygrunwald/go-trending v0.0.0
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/sdk v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+)
+
+require (
+	github.com/PuerkitoBio/goquery v1.10.3 // indirect
+	github.com/andybalholm/cascadia v1.3.3 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel/metric v1.38.0 // indirect
+	golang.org/x/net v0.39.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+// The adapter is developed together with the main module.
+replace github.com/andygrunwald/go-trending => ../
@@ -0,0 +1,99 @@
+// Package otel adapts OpenTelemetry to the trending.Tracer interface.
+//
+// It is a separate module, so the go-trending module itself doesn't depend on OpenTelemetry:
+//
+//	trend := trending.NewTrending()
+//	trend.Tracer = otel.NewTracer(nil) // uses the global TracerProvider
+//
+//	ctx, span := tracer.Start(ctx, "weekly-report")
+//	defer span.End()
+//	result, err := trend.FetchProjectsContext(ctx, trending.Query{Since: trending.TimeWeek})
+//
+// The spans for generating the URL, fetching and parsing a page become children of the span in ctx.
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// ScopeName is the instrumentation scope of the spans created by Tracer.
+const ScopeName = "github.com/andygrunwald/go-trending"
+
+// Tracer is a trending.Tracer creating OpenTelemetry spans. Use NewTracer to create one.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a Tracer creating spans with provider.
+// If provider is nil, the global TracerProvider is used.
+func NewTracer(provider trace.TracerProvider) *Tracer {
+	if provider == nil {
+		provider = otelapi.GetTracerProvider()
+	}
+	return &Tracer{
+		tracer: provider.Tracer(ScopeName),
+	}
+}
+
+// Start implements trending.Tracer.
+// The span of fetching a page is a client span, all other spans are internal.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, trending.Span) {
+	kind := trace.SpanKindInternal
+	if name == trending.SpanFetch {
+		kind = trace.SpanKindClient
+	}
+
+	ctx, s := t.tracer.Start(ctx, name, trace.WithSpanKind(kind))
+	return ctx, span{s}
+}
+
+// span adapts an OpenTelemetry span to trending.Span.
+type span struct {
+	span trace.Span
+}
+
+// SetAttributes implements trending.Span.
+func (s span) SetAttributes(attributes ...trending.Attribute) {
+	kvs := make([]attribute.KeyValue, 0, len(attributes))
+	for _, a := range attributes {
+		kvs = append(kvs, keyValue(a))
+	}
+	s.span.SetAttributes(kvs...)
+}
+
+// RecordError implements trending.Span. It sets the status of the span to error as well.
+func (s span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End implements trending.Span.
+func (s span) End() {
+	s.span.End()
+}
+
+// keyValue converts a into an OpenTelemetry attribute.
+func keyValue(a trending.Attribute) attribute.KeyValue {
+	switch v := a.Value.(type) {
+	case string:
+		return attribute.String(a.Key, v)
+	case int:
+		return attribute.Int(a.Key, v)
+	case int64:
+		return attribute.Int64(a.Key, v)
+	case bool:
+		return attribute.Bool(a.Key, v)
+	case float64:
+		return attribute.Float64(a.Key, v)
+	default:
+		return attribute.String(a.Key, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,107 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// newTestTracer returns a Tracer recording all ended spans in the returned recorder.
+func newTestTracer() (*Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return NewTracer(provider), recorder
+}
+
+// attributes returns the attributes of s as map.
+func attributes(s sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value)
+	for _, kv := range s.Attributes() {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestTracer_Trending(t *testing.T) {
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "../testdata/github.com_trending.html")
+	}))
+	defer github.Close()
+
+	tracer, recorder := newTestTracer()
+	client := trending.NewTrending()
+	client.BaseURL, _ = url.Parse(github.URL)
+	client.Tracer = tracer
+
+	ctx, root := tracer.tracer.Start(context.Background(), "report")
+	if _, err := client.FetchProjectsContext(ctx, trending.Query{Since: trending.TimeWeek, Language: "go"}); err != nil {
+		t.Fatalf("FetchProjectsContext returned error: %v", err)
+	}
+	root.End()
+
+	spans := recorder.Ended()
+	byName := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range spans {
+		byName[s.Name()] = s
+	}
+	for _, name := range []string{"report", "trending.projects", trending.SpanGenerateURL, trending.SpanFetch, trending.SpanParse} {
+		if _, ok := byName[name]; !ok {
+			t.Fatalf("Recorded spans %v, missing %s", spans, name)
+		}
+	}
+
+	if got, want := byName["trending.projects"].Parent().SpanID(), byName["report"].SpanContext().SpanID(); got != want {
+		t.Errorf("Span trending.projects has parent %s, want %s (report)", got, want)
+	}
+	if got, want := byName[trending.SpanFetch].Parent().SpanID(), byName["trending.projects"].SpanContext().SpanID(); got != want {
+		t.Errorf("Span %s has parent %s, want %s (trending.projects)", trending.SpanFetch, got, want)
+	}
+	if kind := byName[trending.SpanFetch].SpanKind(); kind != trace.SpanKindClient {
+		t.Errorf("Span %s has kind %s, want %s", trending.SpanFetch, kind, trace.SpanKindClient)
+	}
+
+	fetch := attributes(byName[trending.SpanFetch])
+	if got := fetch["http.response.status_code"].AsInt64(); got != http.StatusOK {
+		t.Errorf("Span %s has status code %d, want %d", trending.SpanFetch, got, http.StatusOK)
+	}
+	parse := attributes(byName[trending.SpanParse])
+	if got := parse["trending.items"].AsInt64(); got != 25 {
+		t.Errorf("Span %s has %d items, want 25", trending.SpanParse, got)
+	}
+	query := attributes(byName["trending.projects"])
+	if got := query["trending.query.language"].AsString(); got != "go" {
+		t.Errorf("Span trending.projects has language %q, want %q", got, "go")
+	}
+}
+
+func TestSpan_RecordError(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	_, s := tracer.Start(context.Background(), trending.SpanParse)
+	s.SetAttributes(trending.Attribute{Key: "ratio", Value: 0.5}, trending.Attribute{Key: "other", Value: []int{1}})
+	s.RecordError(errors.New("no projects found"))
+	s.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Recorded %d spans, want 1", len(spans))
+	}
+	if status := spans[0].Status(); status.Code != codes.Error || status.Description != "no projects found" {
+		t.Errorf("Span has status %+v, want error with description", status)
+	}
+	attrs := attributes(spans[0])
+	if attrs["ratio"].AsFloat64() != 0.5 || attrs["other"].AsString() != "[1]" {
+		t.Errorf("Span has attributes %v, want ratio 0.5 and other [1]", attrs)
+	}
+}
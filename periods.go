@@ -0,0 +1,106 @@
+package trending
+
+import (
+	"context"
+	"strings"
+)
+
+// PeriodRank is the position of a project on the trending page of a single period.
+type PeriodRank struct {
+	// Rank is the 1-based position on the trending page.
+	Rank int `json:"rank"`
+
+	// Stars is the number of stars the project received within the period (Project.PeriodStars).
+	Stars int `json:"stars"`
+}
+
+// TrendingRepo is a project trending in at least one of the periods TimeToday, TimeWeek and TimeMonth.
+// It merges the appearances of the same repository in the results of all periods.
+type TrendingRepo struct {
+	// Project is the project as listed for the shortest period it is trending in.
+	// Project.PeriodStars are the stars of this period.
+	Project Project `json:"project"`
+
+	// Daily is the position on the trending page of today (TimeToday). Nil if the project isn't trending today.
+	Daily *PeriodRank `json:"daily,omitempty"`
+
+	// Weekly is the position on the trending page of this week (TimeWeek). Nil if the project isn't trending this week.
+	Weekly *PeriodRank `json:"weekly,omitempty"`
+
+	// Monthly is the position on the trending page of this month (TimeMonth). Nil if the project isn't trending this month.
+	Monthly *PeriodRank `json:"monthly,omitempty"`
+
+	// NewToday is true if the project is trending today, but neither this week nor this month.
+	NewToday bool `json:"new_today"`
+
+	// SustainedThisMonth is true if the project is trending this month and still this week.
+	SustainedThisMonth bool `json:"sustained_this_month"`
+}
+
+// Period returns the position for since (TimeToday, TimeWeek or TimeMonth) or nil if the project isn't trending in the period.
+func (r TrendingRepo) Period(since string) *PeriodRank {
+	switch since {
+	case TimeToday:
+		return r.Daily
+	case TimeWeek:
+		return r.Weekly
+	case TimeMonth:
+		return r.Monthly
+	}
+	return nil
+}
+
+// GetProjectsAllPeriods provides the trending projects of today, this week and this month as one list.
+// Every repository is listed once, see MergePeriods. Query.Since of q is ignored.
+func (t *Trending) GetProjectsAllPeriods(q Query) ([]TrendingRepo, error) {
+	return t.GetProjectsAllPeriodsContext(context.Background(), q)
+}
+
+// GetProjectsAllPeriodsContext is like GetProjectsAllPeriods, but the requests are bound to ctx.
+func (t *Trending) GetProjectsAllPeriodsContext(ctx context.Context, q Query) ([]TrendingRepo, error) {
+	var results [3][]Project
+	for i, since := range []string{TimeToday, TimeWeek, TimeMonth} {
+		q.Since = since
+		projects, _, err := t.getProjects(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = projects
+	}
+	return MergePeriods(results[0], results[1], results[2]), nil
+}
+
+// MergePeriods merges the trending projects of today, this week and this month into one TrendingRepo per repository.
+// Repositories are identified by their name (case insensitive).
+// The result lists the daily projects first, followed by the weekly and the monthly ones that aren't listed yet,
+// each in the order of their trending page.
+func MergePeriods(daily, weekly, monthly []Project) []TrendingRepo {
+	var repos []TrendingRepo
+	index := make(map[string]int)
+
+	add := func(projects []Project, period func(*TrendingRepo) **PeriodRank) {
+		for i, p := range projects {
+			key := strings.ToLower(p.Name)
+			n, ok := index[key]
+			if !ok {
+				n = len(repos)
+				index[key] = n
+				repos = append(repos, TrendingRepo{Project: p})
+			}
+			rank := period(&repos[n])
+			if *rank == nil {
+				*rank = &PeriodRank{Rank: i + 1, Stars: p.PeriodStars}
+			}
+		}
+	}
+	add(daily, func(r *TrendingRepo) **PeriodRank { return &r.Daily })
+	add(weekly, func(r *TrendingRepo) **PeriodRank { return &r.Weekly })
+	add(monthly, func(r *TrendingRepo) **PeriodRank { return &r.Monthly })
+
+	for i := range repos {
+		r := &repos[i]
+		r.NewToday = r.Daily != nil && r.Weekly == nil && r.Monthly == nil
+		r.SustainedThisMonth = r.Monthly != nil && r.Weekly != nil
+	}
+	return repos
+}
@@ -0,0 +1,68 @@
+package trending
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMergePeriods(t *testing.T) {
+	daily := []Project{{Name: "a/new", Stars: 50000, PeriodStars: 10}, {Name: "b/sustained", Stars: 50000, PeriodStars: 20}}
+	weekly := []Project{{Name: "B/Sustained", Stars: 50000, PeriodStars: 200}, {Name: "c/weekly", Stars: 50000, PeriodStars: 100}}
+	monthly := []Project{{Name: "d/monthly", Stars: 50000, PeriodStars: 3000}, {Name: "b/sustained", Stars: 50000, PeriodStars: 2000}}
+
+	got := MergePeriods(daily, weekly, monthly)
+	want := []TrendingRepo{
+		{Project: daily[0], Daily: &PeriodRank{Rank: 1, Stars: 10}, NewToday: true},
+		{
+			Project:            daily[1],
+			Daily:              &PeriodRank{Rank: 2, Stars: 20},
+			Weekly:             &PeriodRank{Rank: 1, Stars: 200},
+			Monthly:            &PeriodRank{Rank: 2, Stars: 2000},
+			SustainedThisMonth: true,
+		},
+		{Project: weekly[1], Weekly: &PeriodRank{Rank: 2, Stars: 100}},
+		{Project: monthly[0], Monthly: &PeriodRank{Rank: 1, Stars: 3000}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergePeriods returned %+v, want %+v", got, want)
+	}
+
+	if p := got[1].Period(TimeWeek); p == nil || p.Rank != 1 {
+		t.Errorf("Period(TimeWeek) returned %+v, want rank 1", p)
+	}
+	if p := got[0].Period(TimeMonth); p != nil {
+		t.Errorf("Period(TimeMonth) returned %+v, want nil", p)
+	}
+}
+
+func TestGetProjectsAllPeriods(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var periods []string
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		periods = append(periods, r.FormValue("since"))
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	repos, err := client.GetProjectsAllPeriods(Query{Since: TimeWeek, Language: "go"})
+	if err != nil {
+		t.Fatalf("GetProjectsAllPeriods returned error: %v", err)
+	}
+
+	if want := []string{TimeToday, TimeWeek, TimeMonth}; !reflect.DeepEqual(periods, want) {
+		t.Errorf("GetProjectsAllPeriods requested the periods %v, want %v", periods, want)
+	}
+	// All periods return the same page, so every project is trending in every period
+	if len(repos) != 25 {
+		t.Fatalf("GetProjectsAllPeriods returned %d repositories, want 25", len(repos))
+	}
+	for _, r := range repos {
+		if r.Daily == nil || r.Weekly == nil || r.Monthly == nil || r.NewToday || !r.SustainedThisMonth {
+			t.Errorf("GetProjectsAllPeriods returned %+v, want a sustained project trending in all periods", r)
+		}
+	}
+}
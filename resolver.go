@@ -0,0 +1,325 @@
+package trending
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// catalogJSON is the bundled catalog of programing languages known by GitHub.
+//
+//go:embed data/languages.json
+var catalogJSON []byte
+
+// LanguageInfo describes a programing language of the bundled catalog.
+type LanguageInfo struct {
+	// Name is the human readable name of the language like "Go" or "C#".
+	Name string `json:"name"`
+
+	// URLName is the machine readable name of the language used for filtering like "go" or "c%23".
+	URLName string `json:"url_name"`
+
+	// Color is the hex color of the language like "#00ADD8" for "Go". Empty if GitHub has no color for the language.
+	Color string `json:"color,omitempty"`
+
+	// Type is the linguist type of the language like LanguageTypeProgramming. Empty if unknown.
+	Type string `json:"type,omitempty"`
+
+	// Popular is true for the popular languages of GitHub linguist, which are shown on top of the language dropdown.
+	Popular bool `json:"popular,omitempty"`
+
+	// Aliases are common other names of the language like "golang" for "Go" or "csharp" for "C#".
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Types of languages as classified by GitHub linguist.
+const (
+	LanguageTypeProgramming = "programming"
+	LanguageTypeMarkup      = "markup"
+	LanguageTypeData        = "data"
+	LanguageTypeProse       = "prose"
+)
+
+// catalog returns the parsed bundled catalog. It is parsed once on first use.
+var catalog = sync.OnceValue(func() []LanguageInfo {
+	var languages []LanguageInfo
+	if err := json.Unmarshal(catalogJSON, &languages); err != nil {
+		panic(fmt.Sprintf("trending: invalid language catalog: %v", err))
+	}
+	return languages
+})
+
+// catalogIndex maps the URL names of the bundled catalog to their position in the catalog.
+var catalogIndex = sync.OnceValue(func() map[string]int {
+	index := make(map[string]int)
+	for i, l := range catalog() {
+		index[l.URLName] = i
+	}
+	return index
+})
+
+// Catalog returns the bundled catalog of programing languages known by GitHub.
+// It works offline and is a snapshot of GetLanguages, extended by colors and types
+// of GitHub linguist (https://github.com/github-linguist/linguist) and common aliases.
+// GetLanguages fills Language.Color and Language.Type from the catalog as well.
+func Catalog() []LanguageInfo {
+	return append([]LanguageInfo(nil), catalog()...)
+}
+
+// LookupLanguage returns the catalog entry of the language name.
+// name can be anything ResolveLanguage accepts, like "Go", "golang" or "go".
+func LookupLanguage(name string) (LanguageInfo, bool) {
+	urlName, err := ResolveLanguage(name)
+	if err != nil || len(urlName) == 0 {
+		return LanguageInfo{}, false
+	}
+	return lookupCatalog(urlName)
+}
+
+// lookupCatalog returns the catalog entry with the URL name urlName.
+func lookupCatalog(urlName string) (LanguageInfo, bool) {
+	i, ok := catalogIndex()[urlName]
+	if !ok {
+		return LanguageInfo{}, false
+	}
+	return catalog()[i], true
+}
+
+// UnknownLanguageError is returned by LanguageResolver.Resolve if the input matches no language.
+type UnknownLanguageError struct {
+	// Input is the unknown language.
+	Input string
+
+	// Suggestions are the URL names of similar languages, the most similar first.
+	Suggestions []string
+
+	// URLName is the input in the form of a URL name like "new-lang" for "New Lang",
+	// which Resolve returns along with the error. It is empty if the input can't be a URL name.
+	URLName string
+}
+
+// Error returns a message like `trending: unknown language "golan", did you mean "go"?`.
+func (e *UnknownLanguageError) Error() string {
+	msg := fmt.Sprintf("trending: unknown language %q", e.Input)
+	if len(e.Suggestions) == 0 {
+		return msg
+	}
+
+	quoted := make([]string, 0, len(e.Suggestions))
+	for _, s := range e.Suggestions {
+		quoted = append(quoted, fmt.Sprintf("%q", s))
+	}
+	return msg + ", did you mean " + strings.Join(quoted, " or ") + "?"
+}
+
+// maxSuggestions is the maximum number of suggestions of an UnknownLanguageError.
+const maxSuggestions = 3
+
+// LanguageResolver maps user input like "C#", "golang" or "JS" to the URLName of a language like "c%23", "go" or "javascript".
+//
+// Display names, URL names, aliases of the bundled catalog and case or encoding variants are resolved.
+// Use NewLanguageResolver, Trending.LanguageResolver or the package level ResolveLanguage.
+type LanguageResolver struct {
+	// urlNames maps normalized names to the URL name.
+	urlNames map[string]string
+
+	// keys are the normalized names in the order of the catalog, used for suggestions.
+	keys []string
+}
+
+// NewLanguageResolver returns a resolver for languages and all languages of the bundled catalog.
+// languages, like the result of GetLanguages, take precedence over the catalog.
+func NewLanguageResolver(languages []Language) *LanguageResolver {
+	r := &LanguageResolver{
+		urlNames: make(map[string]string),
+	}
+
+	for _, l := range languages {
+		if len(l.URLName) > 0 {
+			r.add(l.URLName, l.URLName, l.Name)
+		}
+	}
+	for _, l := range catalog() {
+		r.add(l.URLName, append([]string{l.URLName, l.Name}, l.Aliases...)...)
+	}
+	return r
+}
+
+// LanguageResolver returns a resolver for the languages of GetLanguages and the bundled catalog.
+// Use NewLanguageResolver(nil) to resolve offline with the catalog only.
+func (t *Trending) LanguageResolver() (*LanguageResolver, error) {
+	languages, err := t.GetLanguages()
+	if err != nil {
+		return nil, err
+	}
+	return NewLanguageResolver(languages), nil
+}
+
+// defaultResolver is the resolver of ResolveLanguage.
+var defaultResolver = sync.OnceValue(func() *LanguageResolver {
+	return NewLanguageResolver(nil)
+})
+
+// ResolveLanguage resolves input with the bundled catalog. See LanguageResolver.Resolve.
+func ResolveLanguage(input string) (string, error) {
+	return defaultResolver().Resolve(input)
+}
+
+// add registers names as names of the language urlName. Names already known are not overwritten.
+func (r *LanguageResolver) add(urlName string, names ...string) {
+	for _, name := range names {
+		for _, key := range []string{normalizeLanguage(name), compactLanguage(name)} {
+			if len(key) == 0 {
+				continue
+			}
+			if _, ok := r.urlNames[key]; ok {
+				continue
+			}
+			r.urlNames[key] = urlName
+			r.keys = append(r.keys, key)
+		}
+	}
+}
+
+// Resolve returns the URLName of the language input like "c%23" for "C#", "csharp" or "c%23".
+// An empty input resolves to an empty string (all languages).
+// If no language matches, an *UnknownLanguageError with suggestions is returned.
+// The catalog may lag behind GitHub, so if input looks like a URL name, it is returned
+// along with the error (see UnknownLanguageError.URLName) and callers can decide to use it anyway.
+func (r *LanguageResolver) Resolve(input string) (string, error) {
+	if len(strings.TrimSpace(input)) == 0 {
+		return "", nil
+	}
+
+	if urlName, ok := r.urlNames[normalizeLanguage(input)]; ok {
+		return urlName, nil
+	}
+	if urlName, ok := r.urlNames[compactLanguage(input)]; ok {
+		return urlName, nil
+	}
+
+	urlName := guessURLName(input)
+	return urlName, &UnknownLanguageError{
+		Input:       input,
+		Suggestions: r.Suggest(input, maxSuggestions),
+		URLName:     urlName,
+	}
+}
+
+// guessURLName returns the URL name GitHub would use for the language name,
+// like "new-lang" for "New Lang" or "q%23" for "Q#".
+// It returns an empty string if name contains characters that no URL name of the catalog contains.
+func guessURLName(name string) string {
+	urlName := strings.ReplaceAll(normalizeLanguage(name), "#", "%23")
+	for _, r := range strings.ReplaceAll(urlName, "%23", "") {
+		switch {
+		case 'a' <= r && r <= 'z', '0' <= r && r <= '9', strings.ContainsRune("'()*+-.", r):
+		default:
+			return ""
+		}
+	}
+	return urlName
+}
+
+// Suggest returns the URL names of up to n languages similar to input, the most similar first.
+func (r *LanguageResolver) Suggest(input string, n int) []string {
+	needle := normalizeLanguage(input)
+	if len(needle) == 0 || n <= 0 {
+		return nil
+	}
+
+	// Allow roughly one typo per three characters
+	maxDistance := len(needle) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	type candidate struct {
+		urlName  string
+		distance int
+	}
+	best := make(map[string]int)
+	for _, key := range r.keys {
+		d := editDistance(needle, key)
+		if strings.HasPrefix(key, needle) && len(needle) >= 3 {
+			// Treat "typesc" like a typo of "typescript"
+			d = min(d, 1)
+		}
+		if d > maxDistance {
+			continue
+		}
+		urlName := r.urlNames[key]
+		if old, ok := best[urlName]; !ok || d < old {
+			best[urlName] = d
+		}
+	}
+
+	candidates := make([]candidate, 0, len(best))
+	for urlName, d := range best {
+		candidates = append(candidates, candidate{urlName, d})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].urlName < candidates[j].urlName
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < n; i++ {
+		suggestions = append(suggestions, candidates[i].urlName)
+	}
+	return suggestions
+}
+
+// normalizeLanguage returns the comparable form of a language name:
+// URL decoded, lower case and with blanks replaced by "-", like "visual-basic-.net" for "Visual Basic .NET".
+func normalizeLanguage(name string) string {
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// compactLanguage returns the normalized name without separators, like "visualbasicnet" for "Visual Basic .NET".
+func compactLanguage(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.', ' ':
+			return -1
+		}
+		return r
+	}, normalizeLanguage(name))
+}
+
+// editDistance returns the optimal string alignment distance between a and b:
+// the number of inserted, deleted, substituted or swapped adjacent characters, like 1 for "rsut" and "rust".
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,180 @@
+package trending
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestResolveLanguage(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: ""},
+		{input: "go", want: "go"},
+		{input: "Go", want: "go"},
+		{input: "golang", want: "go"},
+		{input: "C#", want: "c%23"},
+		{input: "c%23", want: "c%23"},
+		{input: "csharp", want: "c%23"},
+		{input: "C++", want: "c++"},
+		{input: "cpp", want: "c++"},
+		{input: "Visual Basic .NET", want: "visual-basic-.net"},
+		{input: "visualbasicnet", want: "visual-basic-.net"},
+		{input: " JavaScript ", want: "javascript"},
+		{input: "js", want: "javascript"},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveLanguage(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveLanguage(%q) returned %q, %v, want %q, nil", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolveLanguage_Unknown(t *testing.T) {
+	_, err := ResolveLanguage("golan")
+
+	var unknown *UnknownLanguageError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("ResolveLanguage returned %v, want an *UnknownLanguageError", err)
+	}
+	if len(unknown.Suggestions) == 0 || unknown.Suggestions[0] != "go" {
+		t.Errorf("ResolveLanguage returned suggestions %v, want %q first", unknown.Suggestions, "go")
+	}
+	if want := `trending: unknown language "golan", did you mean "go"`; len(err.Error()) < len(want) || err.Error()[:len(want)] != want {
+		t.Errorf("ResolveLanguage returned error %q, want prefix %q", err, want)
+	}
+
+	_, err = ResolveLanguage("definitely-not-a-language")
+	if !errors.As(err, &unknown) || len(unknown.Suggestions) != 0 {
+		t.Errorf("ResolveLanguage returned %v, want an *UnknownLanguageError without suggestions", err)
+	}
+}
+
+func TestResolveLanguage_UnknownURLName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		// Languages newer than the catalog are returned as URL names along with the error
+		{input: "golan", want: "golan"},
+		{input: "New Lang", want: "new-lang"},
+		{input: "Q2#", want: "q2%23"},
+		{input: "my.lang++", want: "my.lang++"},
+		{input: "go/../..", want: ""},
+		{input: "go?since=weekly", want: ""},
+		{input: "Gö", want: ""},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveLanguage(tt.input)
+		var unknown *UnknownLanguageError
+		if !errors.As(err, &unknown) {
+			t.Fatalf("ResolveLanguage(%q) returned %v, want an *UnknownLanguageError", tt.input, err)
+		}
+		if got != tt.want || unknown.URLName != tt.want {
+			t.Errorf("ResolveLanguage(%q) returned %q and URLName %q, want %q", tt.input, got, unknown.URLName, tt.want)
+		}
+	}
+}
+
+func TestLanguageResolver_Suggest(t *testing.T) {
+	r := NewLanguageResolver(nil)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "pyhton", want: "python"},
+		{input: "typesc", want: "typescript"},
+		{input: "rsut", want: "rust"},
+	}
+
+	for _, tt := range tests {
+		got := r.Suggest(tt.input, 3)
+		if len(got) == 0 || got[0] != tt.want {
+			t.Errorf("LanguageResolver.Suggest(%q) returned %v, want %q first", tt.input, got, tt.want)
+		}
+		if len(got) > 3 {
+			t.Errorf("LanguageResolver.Suggest(%q) returned %d suggestions, want at most 3", tt.input, len(got))
+		}
+	}
+}
+
+func TestTrending_LanguageResolver(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	r, err := client.LanguageResolver()
+	if err != nil {
+		t.Fatalf("LanguageResolver returned error: %v", err)
+	}
+
+	if got, err := r.Resolve("GoLang"); err != nil || got != "go" {
+		t.Errorf("LanguageResolver.Resolve returned %q, %v, want %q, nil", got, err, "go")
+	}
+}
+
+func TestNewLanguageResolver_Precedence(t *testing.T) {
+	r := NewLanguageResolver([]Language{{Name: "Gleam", URLName: "gleam-lang"}})
+
+	if got, err := r.Resolve("Gleam"); err != nil || got != "gleam-lang" {
+		t.Errorf("LanguageResolver.Resolve returned %q, %v, want %q, nil", got, err, "gleam-lang")
+	}
+	if got, err := r.Resolve("go"); err != nil || got != "go" {
+		t.Errorf("LanguageResolver.Resolve returned %q, %v, want the catalog entry %q, nil", got, err, "go")
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	languages := Catalog()
+	if len(languages) < 500 {
+		t.Fatalf("Catalog returned %d languages, want at least 500", len(languages))
+	}
+
+	seen := make(map[string]bool)
+	for _, l := range languages {
+		if len(l.Name) == 0 || len(l.URLName) == 0 {
+			t.Errorf("Catalog contains incomplete language %+v", l)
+		}
+		if seen[l.URLName] {
+			t.Errorf("Catalog contains %q twice", l.URLName)
+		}
+		seen[l.URLName] = true
+	}
+}
+
+func TestLookupLanguage(t *testing.T) {
+	tests := []struct {
+		name  string
+		color string
+		typ   string
+	}{
+		{name: "Go", color: "#00ADD8", typ: LanguageTypeProgramming},
+		{name: "golang", color: "#00ADD8", typ: LanguageTypeProgramming},
+		{name: "Python", color: "#3572A5", typ: LanguageTypeProgramming},
+		{name: "Markdown", color: "#083fa1", typ: LanguageTypeProse},
+		{name: "YAML", color: "#cb171e", typ: LanguageTypeData},
+		{name: "HTML", color: "#e34c26", typ: LanguageTypeMarkup},
+	}
+
+	for _, tt := range tests {
+		info, ok := LookupLanguage(tt.name)
+		if !ok || info.Color != tt.color || info.Type != tt.typ {
+			t.Errorf("LookupLanguage(%q) returned %+v, %v, want color %q and type %q", tt.name, info, ok, tt.color, tt.typ)
+		}
+	}
+
+	if info, ok := LookupLanguage("does-not-exist"); ok {
+		t.Errorf("LookupLanguage returned %+v for an unknown language, want false", info)
+	}
+}
@@ -0,0 +1,76 @@
+package trending
+
+import (
+	"context"
+	"time"
+)
+
+// ProjectsResult is a list of trending projects together with the query and the time it was fetched.
+type ProjectsResult struct {
+	// Query is the query used to fetch the projects.
+	Query Query `json:"query"`
+
+	// FetchedAt is the time the projects were fetched.
+	FetchedAt time.Time `json:"fetched_at"`
+
+	// Projects are the trending projects in the order of the trending page.
+	Projects []Project `json:"projects"`
+}
+
+// DevelopersResult is a list of trending developers together with the query and the time it was fetched.
+type DevelopersResult struct {
+	// Query is the query used to fetch the developers.
+	Query Query `json:"query"`
+
+	// FetchedAt is the time the developers were fetched.
+	FetchedAt time.Time `json:"fetched_at"`
+
+	// Developers are the trending developers in the order of the trending page.
+	Developers []Developer `json:"developers"`
+}
+
+// FetchProjects provides the trending projects of the given query as ProjectsResult.
+// See GetProjectsByQuery for the filter options.
+// If the page was served by the Cache, FetchedAt is the time the page was originally fetched.
+func (t *Trending) FetchProjects(q Query) (*ProjectsResult, error) {
+	return t.FetchProjectsContext(context.Background(), q)
+}
+
+// FetchProjectsContext is like FetchProjects, but the request is bound to ctx.
+// Spans of the Tracer are started as children of the span in ctx.
+func (t *Trending) FetchProjectsContext(ctx context.Context, q Query) (*ProjectsResult, error) {
+	projects, fetchedAt, err := t.getProjects(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ProjectsResult{
+		Query:     q,
+		FetchedAt: fetchedAt,
+		Projects:  projects,
+	}
+	return r, nil
+}
+
+// FetchDevelopers provides the trending developers of the given query as DevelopersResult.
+// See GetDevelopersByQuery for the filter options.
+// If the page was served by the Cache, FetchedAt is the time the page was originally fetched.
+func (t *Trending) FetchDevelopers(q Query) (*DevelopersResult, error) {
+	return t.FetchDevelopersContext(context.Background(), q)
+}
+
+// FetchDevelopersContext is like FetchDevelopers, but the request is bound to ctx.
+// Spans of the Tracer are started as children of the span in ctx.
+func (t *Trending) FetchDevelopersContext(ctx context.Context, q Query) (*DevelopersResult, error) {
+	developers, fetchedAt, err := t.getDevelopers(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &DevelopersResult{
+		Query:      q,
+		FetchedAt:  fetchedAt,
+		Developers: developers,
+	}
+	return r, nil
+}
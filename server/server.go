@@ -0,0 +1,391 @@
+// Package server exposes a Trending client as JSON REST API.
+//
+// The endpoints are:
+//
+//	GET /v1/projects?since=weekly&language=go&spoken=en   trending repositories
+//	GET /v1/developers?since=weekly&language=go&sponsorable=true  trending developers
+//	GET /v1/languages                                     programing languages available for filtering
+//	GET /v1/spoken-languages                              spoken languages available for filtering
+//	GET /v1/projects/feed?type=atom&since=weekly          trending repositories as RSS, Atom or JSON Feed
+//	GET /v1/developers/feed?type=rss                      trending developers as RSS, Atom or JSON Feed
+//	GET /healthz                                          health check, never talks to GitHub
+//	GET /metrics                                          metrics, if Options.MetricsHandler is set
+//
+// All query parameters are optional. Successful responses look like
+//
+//	{
+//	  "meta": {"query": {"since": "weekly", "language": "go"}, "fetched_at": "2026-10-18T07:30:00Z", "count": 25},
+//	  "data": [...]
+//	}
+//
+// Errors are reported as {"error": {"status": 502, "message": "..."}}.
+// Invalid parameters result in 400 Bad Request. Languages are resolved with trending.ResolveLanguage,
+// so names and aliases like "C#" or "golang" are accepted. Unknown languages are passed on to GitHub
+// if they look like URL names and rejected with suggestions otherwise.
+// Failures of GitHub are mapped to 404 Not Found (page not found), 503 Service Unavailable (rate limited, including Retry-After),
+// 504 Gateway Timeout (timeouts) and 502 Bad Gateway (everything else).
+//
+// Pages fetched from GitHub are cached (see trending.Cache), so the server can be polled
+// frequently without hitting GitHub on every request:
+//
+//	srv := server.New(trending.NewTrending(), server.Options{})
+//	log.Fatal(http.ListenAndServe(":8080", srv))
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/feed"
+)
+
+// DefaultCacheTTL is the default time to live of cached GitHub pages.
+const DefaultCacheTTL = 10 * time.Minute
+
+// Options configure a Server.
+type Options struct {
+	// CacheTTL is the time to live of cached GitHub pages.
+	// It is only used if the client has no Cache configured. Defaults to DefaultCacheTTL.
+	// A negative value disables caching.
+	CacheTTL time.Duration
+
+	// AllowedOrigins are the origins allowed to call the API from a browser (CORS),
+	// like "https://dashboard.example.com". Defaults to all origins ("*").
+	AllowedOrigins []string
+
+	// PublicURL is the external address of the server like "https://trending.example.com".
+	// It is used for the self links of feeds. Without it, feeds have no self link.
+	PublicURL string
+
+	// MetricsHandler is mounted at /metrics, if set.
+	// Use the Handler of the metrics package together with trending.Trending.Metrics.
+	MetricsHandler http.Handler
+}
+
+// Server is an http.Handler serving the REST API. Use New to create one.
+type Server struct {
+	client *trending.Trending
+	opts   Options
+	mux    *http.ServeMux
+}
+
+// meta is the metadata of a successful response.
+type meta struct {
+	// Query is the query of the result. It is omitted for languages.
+	Query *trending.Query `json:"query,omitempty"`
+
+	// FetchedAt is the time the result was fetched from GitHub. It is omitted for languages.
+	FetchedAt *time.Time `json:"fetched_at,omitempty"`
+
+	// Count is the number of items in data.
+	Count int `json:"count"`
+}
+
+// response is the body of a successful response.
+type response struct {
+	Meta meta `json:"meta"`
+	Data any  `json:"data"`
+}
+
+// errorResponse is the body of a failed response.
+type errorResponse struct {
+	Error struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// New returns a Server serving trending results of client.
+// If client has no Cache, the server uses a copy of client with a trending.MemoryCache (see Options.CacheTTL).
+func New(client *trending.Trending, opts Options) *Server {
+	if opts.CacheTTL == 0 {
+		opts.CacheTTL = DefaultCacheTTL
+	}
+	if len(opts.AllowedOrigins) == 0 {
+		opts.AllowedOrigins = []string{"*"}
+	}
+	opts.PublicURL = strings.TrimSuffix(opts.PublicURL, "/")
+
+	if client.Cache == nil && opts.CacheTTL > 0 {
+		c := *client
+		c.Cache = trending.NewMemoryCache(opts.CacheTTL)
+		client = &c
+	}
+
+	s := &Server{
+		client: client,
+		opts:   opts,
+		mux:    http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /v1/projects", s.handleProjects)
+	s.mux.HandleFunc("GET /v1/developers", s.handleDevelopers)
+	s.mux.HandleFunc("GET /v1/languages", s.handleLanguages)
+	s.mux.HandleFunc("GET /v1/spoken-languages", s.handleSpokenLanguages)
+	s.mux.HandleFunc("GET /v1/projects/feed", s.handleProjectsFeed)
+	s.mux.HandleFunc("GET /v1/developers/feed", s.handleDevelopersFeed)
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	if opts.MetricsHandler != nil {
+		s.mux.Handle("GET /metrics", opts.MetricsHandler)
+	}
+	return s
+}
+
+// ServeHTTP answers CORS preflight requests and dispatches all other requests to the endpoints.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); len(origin) > 0 {
+		if allowed := s.allowedOrigin(origin); len(allowed) > 0 {
+			w.Header().Set("Access-Control-Allow-Origin", allowed)
+			if allowed != "*" {
+				w.Header().Add("Vary", "Origin")
+			}
+		}
+
+		if r.Method == http.MethodOptions && len(r.Header.Get("Access-Control-Request-Method")) > 0 {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			if headers := r.Header.Get("Access-Control-Request-Headers"); len(headers) > 0 {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			w.Header().Set("Access-Control-Max-Age", "86400")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// allowedOrigin returns the value of the Access-Control-Allow-Origin header for origin
+// or an empty string if origin is not allowed.
+func (s *Server) allowedOrigin(origin string) string {
+	for _, o := range s.opts.AllowedOrigins {
+		if o == "*" {
+			return "*"
+		}
+		if strings.EqualFold(o, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(r, false)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.client.FetchProjectsContext(r.Context(), q)
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response{
+		Meta: meta{Query: &result.Query, FetchedAt: &result.FetchedAt, Count: len(result.Projects)},
+		Data: nonNil(result.Projects),
+	})
+}
+
+func (s *Server) handleDevelopers(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(r, true)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.client.FetchDevelopersContext(r.Context(), q)
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response{
+		Meta: meta{Query: &result.Query, FetchedAt: &result.FetchedAt, Count: len(result.Developers)},
+		Data: nonNil(result.Developers),
+	})
+}
+
+func (s *Server) handleLanguages(w http.ResponseWriter, r *http.Request) {
+	languages, err := s.client.GetLanguages()
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response{
+		Meta: meta{Count: len(languages)},
+		Data: nonNil(languages),
+	})
+}
+
+func (s *Server) handleSpokenLanguages(w http.ResponseWriter, r *http.Request) {
+	spokenLanguages, err := s.client.GetSpokenLanguages()
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response{
+		Meta: meta{Count: len(spokenLanguages)},
+		Data: nonNil(spokenLanguages),
+	})
+}
+
+func (s *Server) handleProjectsFeed(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(r, false)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	typ, err := feedType(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.client.FetchProjectsContext(r.Context(), q)
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", feed.ContentType(typ))
+	feed.Projects(w, typ, result, s.feedOptions(r))
+}
+
+func (s *Server) handleDevelopersFeed(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(r, true)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	typ, err := feedType(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.client.FetchDevelopersContext(r.Context(), q)
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", feed.ContentType(typ))
+	feed.Developers(w, typ, result, s.feedOptions(r))
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// feedOptions returns the feed options for the feed requested by r.
+func (s *Server) feedOptions(r *http.Request) feed.Options {
+	opts := feed.Options{BaseURL: s.client.BaseURL}
+	if len(s.opts.PublicURL) > 0 {
+		opts.FeedURL = s.opts.PublicURL + r.URL.RequestURI()
+	}
+	return opts
+}
+
+// parseQuery returns the trending.Query of the query parameters of r.
+// spoken is only accepted for projects, sponsorable only for developers.
+func parseQuery(r *http.Request, developers bool) (trending.Query, error) {
+	v := r.URL.Query()
+	q := trending.Query{
+		Since: v.Get("since"),
+	}
+
+	switch q.Since {
+	case "", trending.TimeToday, trending.TimeWeek, trending.TimeMonth:
+	default:
+		return q, fmt.Errorf("invalid since %q, expected one of %s, %s or %s", q.Since, trending.TimeToday, trending.TimeWeek, trending.TimeMonth)
+	}
+
+	// Unknown languages that look like URL names may be newer than the bundled catalog
+	language, err := trending.ResolveLanguage(v.Get("language"))
+	var unknown *trending.UnknownLanguageError
+	if err != nil && (!errors.As(err, &unknown) || len(unknown.URLName) == 0) {
+		return q, err
+	}
+	q.Language = language
+
+	if developers {
+		if sponsorable := v.Get("sponsorable"); len(sponsorable) > 0 {
+			b, err := strconv.ParseBool(sponsorable)
+			if err != nil {
+				return q, fmt.Errorf("invalid sponsorable %q, expected true or false", sponsorable)
+			}
+			q.Sponsorable = b
+		}
+	} else {
+		q.SpokenLanguage = v.Get("spoken")
+	}
+	return q, nil
+}
+
+// feedType returns the feed type requested by the type parameter of r. Defaults to Atom.
+func feedType(r *http.Request) (string, error) {
+	typ := r.URL.Query().Get("type")
+	if len(typ) == 0 {
+		return feed.Atom, nil
+	}
+	if len(feed.ContentType(typ)) == 0 {
+		return "", fmt.Errorf("invalid type %q, expected one of %s", typ, strings.Join(feed.Formats(), ", "))
+	}
+	return typ, nil
+}
+
+// writeUpstreamError writes err, returned by the trending client, with a matching status code.
+func writeUpstreamError(w http.ResponseWriter, err error) {
+	var statusErr *trending.StatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.StatusCode == http.StatusNotFound:
+			writeError(w, http.StatusNotFound, err)
+		case statusErr.StatusCode == http.StatusTooManyRequests:
+			if len(statusErr.RetryAfter) > 0 {
+				w.Header().Set("Retry-After", statusErr.RetryAfter)
+			}
+			writeError(w, http.StatusServiceUnavailable, err)
+		default:
+			writeError(w, http.StatusBadGateway, err)
+		}
+		return
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		writeError(w, http.StatusGatewayTimeout, err)
+		return
+	}
+	writeError(w, http.StatusBadGateway, err)
+}
+
+// writeError writes err as JSON error response with status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	var res errorResponse
+	res.Error.Status = status
+	res.Error.Message = err.Error()
+	writeJSON(w, status, res)
+}
+
+// writeJSON writes v as JSON response with status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// nonNil returns items or an empty slice if items is nil, so it is encoded as [] instead of null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
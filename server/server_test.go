@@ -0,0 +1,311 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// github is a fake github.com answering with the fixtures of the testdata folder.
+type github struct {
+	mu       sync.Mutex
+	requests []string
+
+	// status overwrites the status code of all responses, if set.
+	status int
+}
+
+func (g *github) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	g.requests = append(g.requests, r.URL.String())
+	status := g.status
+	g.mu.Unlock()
+
+	if status != 0 {
+		w.Header().Set("Retry-After", "120")
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	file := "../testdata/github.com_trending.html"
+	if strings.HasSuffix(r.URL.Path, "/developers") {
+		file = "../testdata/github.com_trending_developers.html"
+	}
+	http.ServeFile(w, r, file)
+}
+
+// newTestServer returns the API server under test backed by a fake GitHub.
+func newTestServer(t *testing.T, opts Options) (*httptest.Server, *github) {
+	t.Helper()
+
+	gh := &github{}
+	upstream := httptest.NewServer(gh)
+	t.Cleanup(upstream.Close)
+
+	client := trending.NewTrending()
+	client.BaseURL, _ = url.Parse(upstream.URL)
+
+	api := httptest.NewServer(New(client, opts))
+	t.Cleanup(api.Close)
+	return api, gh
+}
+
+// get requests path from server and decodes the JSON response into v.
+func get(t *testing.T, server *httptest.Server, path string, v any) *http.Response {
+	t.Helper()
+
+	res, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatalf("GET %s returned error: %v", path, err)
+	}
+	defer res.Body.Close()
+
+	if v != nil {
+		if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+			t.Fatalf("GET %s returned invalid JSON: %v", path, err)
+		}
+	}
+	return res
+}
+
+func TestServer_Projects(t *testing.T) {
+	api, gh := newTestServer(t, Options{})
+
+	var body struct {
+		Meta struct {
+			Query     trending.Query `json:"query"`
+			FetchedAt time.Time      `json:"fetched_at"`
+			Count     int            `json:"count"`
+		} `json:"meta"`
+		Data []trending.Project `json:"data"`
+	}
+	res := get(t, api, "/v1/projects?since=weekly&language=go&spoken=en", &body)
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("GET /v1/projects returned status %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if ct := res.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("GET /v1/projects returned Content-Type %q, want application/json", ct)
+	}
+	want := trending.Query{Since: trending.TimeWeek, Language: "go", SpokenLanguage: "en"}
+	if body.Meta.Query != want {
+		t.Errorf("GET /v1/projects returned query %+v, want %+v", body.Meta.Query, want)
+	}
+	if body.Meta.Count != 25 || len(body.Data) != 25 || body.Meta.FetchedAt.IsZero() {
+		t.Errorf("GET /v1/projects returned meta %+v with %d projects, want 25 projects and a fetch time", body.Meta, len(body.Data))
+	}
+	if body.Data[0].Name != "smol-ai/developer" {
+		t.Errorf("GET /v1/projects returned first project %q, want %q", body.Data[0].Name, "smol-ai/developer")
+	}
+	if want := "/trending?l=go&since=weekly&spoken_language_code=en"; gh.requests[0] != want {
+		t.Errorf("GitHub was requested with %s, want %s", gh.requests[0], want)
+	}
+}
+
+func TestServer_Developers(t *testing.T) {
+	api, gh := newTestServer(t, Options{})
+
+	var body struct {
+		Meta struct {
+			Query trending.Query `json:"query"`
+			Count int            `json:"count"`
+		} `json:"meta"`
+		Data []trending.Developer `json:"data"`
+	}
+	res := get(t, api, "/v1/developers?since=monthly&sponsorable=true", &body)
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("GET /v1/developers returned status %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if !body.Meta.Query.Sponsorable || body.Meta.Count != 25 || len(body.Data) != 25 {
+		t.Errorf("GET /v1/developers returned meta %+v with %d developers, want 25 sponsorable developers", body.Meta, len(body.Data))
+	}
+	if want := "/trending/developers?since=monthly&sponsorable=1"; gh.requests[0] != want {
+		t.Errorf("GitHub was requested with %s, want %s", gh.requests[0], want)
+	}
+}
+
+func TestServer_Languages(t *testing.T) {
+	api, _ := newTestServer(t, Options{})
+
+	var languages struct {
+		Meta struct {
+			Count int `json:"count"`
+		} `json:"meta"`
+		Data []trending.Language `json:"data"`
+	}
+	if res := get(t, api, "/v1/languages", &languages); res.StatusCode != http.StatusOK {
+		t.Fatalf("GET /v1/languages returned status %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if languages.Meta.Count == 0 || languages.Meta.Count != len(languages.Data) {
+		t.Errorf("GET /v1/languages returned count %d with %d languages, want equal and not 0", languages.Meta.Count, len(languages.Data))
+	}
+
+	var spokenLanguages struct {
+		Data []trending.SpokenLanguage `json:"data"`
+	}
+	if res := get(t, api, "/v1/spoken-languages", &spokenLanguages); res.StatusCode != http.StatusOK {
+		t.Fatalf("GET /v1/spoken-languages returned status %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if len(spokenLanguages.Data) == 0 {
+		t.Error("GET /v1/spoken-languages returned no spoken languages")
+	}
+}
+
+func TestServer_Feed(t *testing.T) {
+	api, _ := newTestServer(t, Options{PublicURL: "https://trending.example.com/"})
+
+	res := get(t, api, "/v1/projects/feed?type=rss&since=weekly", nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("GET /v1/projects/feed returned status %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if ct := res.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/rss+xml") {
+		t.Errorf("GET /v1/projects/feed returned Content-Type %q, want application/rss+xml", ct)
+	}
+
+	var body map[string]any
+	res = get(t, api, "/v1/developers/feed?type=json", &body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("GET /v1/developers/feed returned status %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if want := "https://trending.example.com/v1/developers/feed?type=json"; body["feed_url"] != want {
+		t.Errorf("GET /v1/developers/feed returned feed_url %v, want %s", body["feed_url"], want)
+	}
+}
+
+func TestServer_Cache(t *testing.T) {
+	api, gh := newTestServer(t, Options{})
+
+	for i := 0; i < 3; i++ {
+		if res := get(t, api, "/v1/projects?since=daily", nil); res.StatusCode != http.StatusOK {
+			t.Fatalf("GET /v1/projects returned status %d, want %d", res.StatusCode, http.StatusOK)
+		}
+	}
+	get(t, api, "/v1/projects/feed?since=daily", nil)
+
+	if len(gh.requests) != 1 {
+		t.Errorf("GitHub was requested %d times, want 1: %v", len(gh.requests), gh.requests)
+	}
+
+	api, gh = newTestServer(t, Options{CacheTTL: -1})
+	get(t, api, "/v1/projects", nil)
+	get(t, api, "/v1/projects", nil)
+	if len(gh.requests) != 2 {
+		t.Errorf("GitHub was requested %d times with disabled cache, want 2", len(gh.requests))
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	tests := []struct {
+		path       string
+		upstream   int
+		status     int
+		retryAfter string
+	}{
+		{path: "/v1/projects?since=yearly", status: http.StatusBadRequest},
+		{path: "/v1/developers?sponsorable=maybe", status: http.StatusBadRequest},
+		{path: "/v1/projects/feed?type=opml", status: http.StatusBadRequest},
+		{path: "/v1/projects?language=go%2F..", status: http.StatusBadRequest},
+		{path: "/v1/projects?language=go", upstream: http.StatusNotFound, status: http.StatusNotFound},
+		{path: "/v1/developers", upstream: http.StatusTooManyRequests, status: http.StatusServiceUnavailable, retryAfter: "120"},
+		{path: "/v1/languages", upstream: http.StatusInternalServerError, status: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		api, gh := newTestServer(t, Options{})
+		gh.status = tt.upstream
+
+		var body struct {
+			Error struct {
+				Status  int    `json:"status"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		res := get(t, api, tt.path, &body)
+
+		if res.StatusCode != tt.status || body.Error.Status != tt.status || len(body.Error.Message) == 0 {
+			t.Errorf("GET %s returned status %d and error %+v, want status %d with message", tt.path, res.StatusCode, body.Error, tt.status)
+		}
+		if got := res.Header.Get("Retry-After"); got != tt.retryAfter {
+			t.Errorf("GET %s returned Retry-After %q, want %q", tt.path, got, tt.retryAfter)
+		}
+	}
+}
+
+func TestServer_CORS(t *testing.T) {
+	api, _ := newTestServer(t, Options{AllowedOrigins: []string{"https://dashboard.example.com"}})
+
+	req, _ := http.NewRequest(http.MethodOptions, api.URL+"/v1/projects", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Preflight request returned error: %v", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		t.Errorf("Preflight request returned status %d, want %d", res.StatusCode, http.StatusNoContent)
+	}
+	if got := res.Header.Get("Access-Control-Allow-Origin"); got != "https://dashboard.example.com" {
+		t.Errorf("Preflight request returned Access-Control-Allow-Origin %q, want %q", got, "https://dashboard.example.com")
+	}
+	if got := res.Header.Get("Access-Control-Allow-Methods"); !strings.Contains(got, "GET") {
+		t.Errorf("Preflight request returned Access-Control-Allow-Methods %q, want GET", got)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, api.URL+"/healthz", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request returned error: %v", err)
+	}
+	res.Body.Close()
+
+	if got := res.Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Request of unknown origin returned Access-Control-Allow-Origin %q, want none", got)
+	}
+}
+
+func TestServer_Health(t *testing.T) {
+	api, gh := newTestServer(t, Options{})
+	gh.status = http.StatusInternalServerError
+
+	var body map[string]string
+	res := get(t, api, "/healthz", &body)
+	if res.StatusCode != http.StatusOK || body["status"] != "ok" {
+		t.Errorf("GET /healthz returned status %d and %v, want %d and status ok", res.StatusCode, body, http.StatusOK)
+	}
+	if len(gh.requests) != 0 {
+		t.Errorf("GET /healthz requested GitHub %d times, want 0", len(gh.requests))
+	}
+}
+
+func TestServer_Metrics(t *testing.T) {
+	api, _ := newTestServer(t, Options{})
+	if res := get(t, api, "/metrics", nil); res.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /metrics without MetricsHandler returned status %d, want %d", res.StatusCode, http.StatusNotFound)
+	}
+
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "trending_requests_total 1\n")
+	})
+	api, _ = newTestServer(t, Options{MetricsHandler: metrics})
+	res, err := http.Get(api.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics returned error: %v", err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK || string(body) != "trending_requests_total 1\n" {
+		t.Errorf("GET /metrics returned status %d and %q, want the output of the MetricsHandler", res.StatusCode, body)
+	}
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// bucketSnapshots is the name of the bbolt bucket that holds all snapshots.
+var bucketSnapshots = []byte("snapshots")
+
+// Bolt is a Store backed by a bbolt database, an embedded key/value store in a single file.
+//
+// Snapshots are stored as JSON (see trending.SchemaVersion), keyed by FetchedAt and ID.
+// This way time ranges can be read without decoding snapshots outside of the range.
+type Bolt struct {
+	db *bolt.DB
+}
+
+// Bolt implements Store
+var _ Store = (*Bolt)(nil)
+
+// Open opens the bbolt database at path and creates it if it does not exist.
+// Only one process can open the database at the same time.
+func Open(path string) (*Bolt, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketSnapshots)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Bolt{db: db}, nil
+}
+
+// Save stores s and assigns a new ID to it.
+func (b *Bolt) Save(s *Snapshot) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketSnapshots)
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		stored := *s
+		stored.ID = id
+		value, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put(snapshotKey(stored.FetchedAt, id), value); err != nil {
+			return err
+		}
+
+		s.ID = id
+		return nil
+	})
+	if errors.Is(err, bolt.ErrDatabaseNotOpen) {
+		return ErrClosed
+	}
+	return err
+}
+
+// Snapshots returns all snapshots matching f, ordered by FetchedAt (oldest first).
+func (b *Bolt) Snapshots(f Filter) ([]Snapshot, error) {
+	var snapshots []Snapshot
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketSnapshots).Cursor()
+
+		var k, v []byte
+		if f.From.IsZero() {
+			k, v = c.First()
+		} else {
+			k, v = c.Seek(snapshotKey(f.From, 0))
+		}
+
+		var end []byte
+		if !f.To.IsZero() {
+			end = snapshotKey(f.To, 0)
+		}
+
+		for ; k != nil; k, v = c.Next() {
+			if end != nil && bytes.Compare(k, end) >= 0 {
+				break
+			}
+
+			var s Snapshot
+			if err := json.Unmarshal(v, &s); err != nil {
+				return err
+			}
+			if f.Match(&s) {
+				snapshots = append(snapshots, s)
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, bolt.ErrDatabaseNotOpen) {
+		return nil, ErrClosed
+	}
+
+	return snapshots, err
+}
+
+// Close closes the database file.
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
+
+// snapshotKey returns the key of a snapshot: FetchedAt in nanoseconds followed by the ID, both big endian.
+// Big endian keeps the byte order of the keys in line with the time order.
+func snapshotKey(fetchedAt time.Time, id uint64) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key[:8], uint64(fetchedAt.UnixNano()))
+	binary.BigEndian.PutUint64(key[8:], id)
+	return key
+}
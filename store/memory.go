@@ -0,0 +1,70 @@
+package store
+
+import (
+	"sort"
+	"sync"
+)
+
+// Memory is a Store that keeps all snapshots in memory.
+// It is useful for tests and short living processes. All data is lost on Close.
+type Memory struct {
+	mu        sync.Mutex
+	snapshots []Snapshot
+	lastID    uint64
+	closed    bool
+}
+
+// Memory implements Store
+var _ Store = (*Memory)(nil)
+
+// NewMemory returns an empty Memory store.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Save stores s and assigns a new ID to it.
+func (m *Memory) Save(s *Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+
+	m.lastID++
+	s.ID = m.lastID
+	m.snapshots = append(m.snapshots, *s)
+	return nil
+}
+
+// Snapshots returns all snapshots matching f, ordered by FetchedAt (oldest first).
+func (m *Memory) Snapshots(f Filter) ([]Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrClosed
+	}
+
+	var snapshots []Snapshot
+	for i := range m.snapshots {
+		if f.Match(&m.snapshots[i]) {
+			snapshots = append(snapshots, m.snapshots[i])
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].FetchedAt.Before(snapshots[j].FetchedAt)
+	})
+	return snapshots, nil
+}
+
+// Close drops all snapshots.
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	m.snapshots = nil
+	return nil
+}
@@ -0,0 +1,137 @@
+// Package store persists trending results over time.
+//
+// The trending page of GitHub is a point-in-time view.
+// A Store keeps every fetch result as Snapshot, so that the history can be queried later:
+// which repositories trended, for how long and in which languages.
+//
+//	s, err := store.Open("trending.db")
+//	if err != nil {
+//		...
+//	}
+//	defer s.Close()
+//
+//	projects, err := trend.GetProjects(trending.TimeToday, "go")
+//	...
+//	err = s.Save(store.NewProjectsSnapshot(trending.Query{Since: trending.TimeToday, Language: "go"}, projects, time.Now()))
+//
+//	// All snapshots of Go projects during the last week
+//	snapshots, err := s.Snapshots(store.Filter{
+//		Kind:     store.KindProjects,
+//		Language: "go",
+//		From:     time.Now().AddDate(0, 0, -7),
+//	})
+//
+// Two implementations are available: Bolt, an embedded database in a single file,
+// and Memory, which keeps the snapshots in memory only.
+package store
+
+import (
+	"errors"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// Kinds of snapshots.
+const (
+	// KindProjects is a snapshot of trending projects.
+	KindProjects = "projects"
+	// KindDevelopers is a snapshot of trending developers.
+	KindDevelopers = "developers"
+)
+
+// ErrClosed is returned by all methods of a Store after it was closed.
+var ErrClosed = errors.New("store: closed")
+
+// Store persists snapshots of trending results.
+type Store interface {
+	// Save stores s and assigns a new ID to it.
+	Save(s *Snapshot) error
+
+	// Snapshots returns all snapshots matching f, ordered by FetchedAt (oldest first).
+	Snapshots(f Filter) ([]Snapshot, error)
+
+	// Close releases all resources of the store.
+	Close() error
+}
+
+// Snapshot is a single fetch result of the trending page.
+type Snapshot struct {
+	// ID is the unique identifier of the snapshot within a store. It is assigned by Store.Save.
+	ID uint64 `json:"id"`
+
+	// Kind is the kind of the snapshot, KindProjects or KindDevelopers.
+	Kind string `json:"kind"`
+
+	// Query is the query used to fetch the result.
+	Query trending.Query `json:"query"`
+
+	// FetchedAt is the time the result was fetched.
+	FetchedAt time.Time `json:"fetched_at"`
+
+	// Projects are the trending projects of a KindProjects snapshot.
+	Projects []trending.Project `json:"projects,omitempty"`
+
+	// Developers are the trending developers of a KindDevelopers snapshot.
+	Developers []trending.Developer `json:"developers,omitempty"`
+}
+
+// NewProjectsSnapshot returns a snapshot of projects fetched with q at fetchedAt.
+func NewProjectsSnapshot(q trending.Query, projects []trending.Project, fetchedAt time.Time) *Snapshot {
+	return &Snapshot{
+		Kind:      KindProjects,
+		Query:     q,
+		FetchedAt: fetchedAt,
+		Projects:  projects,
+	}
+}
+
+// NewDevelopersSnapshot returns a snapshot of developers fetched with q at fetchedAt.
+func NewDevelopersSnapshot(q trending.Query, developers []trending.Developer, fetchedAt time.Time) *Snapshot {
+	return &Snapshot{
+		Kind:       KindDevelopers,
+		Query:      q,
+		FetchedAt:  fetchedAt,
+		Developers: developers,
+	}
+}
+
+// Filter selects snapshots. Empty fields match every snapshot.
+type Filter struct {
+	// Kind is the kind of the snapshot, KindProjects or KindDevelopers.
+	Kind string
+
+	// Since is the timeframe of the query, like trending.TimeToday.
+	Since string
+
+	// Language is the Language.URLName of the query, like "go".
+	Language string
+
+	// SpokenLanguage is the SpokenLanguage.Code of the query, like "en".
+	SpokenLanguage string
+
+	// From is the earliest FetchedAt (inclusive).
+	From time.Time
+
+	// To is the latest FetchedAt (exclusive).
+	To time.Time
+}
+
+// Match reports whether s is selected by f.
+func (f Filter) Match(s *Snapshot) bool {
+	switch {
+	case len(f.Kind) > 0 && f.Kind != s.Kind:
+		return false
+	case len(f.Since) > 0 && f.Since != s.Query.Since:
+		return false
+	case len(f.Language) > 0 && f.Language != s.Query.Language:
+		return false
+	case len(f.SpokenLanguage) > 0 && f.SpokenLanguage != s.Query.SpokenLanguage:
+		return false
+	case !f.From.IsZero() && s.FetchedAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && !s.FetchedAt.Before(f.To):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"errors"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+// day returns midnight UTC of the given day in October 2026.
+func day(d int) time.Time {
+	return time.Date(2026, time.October, d, 0, 0, 0, 0, time.UTC)
+}
+
+// testProject returns a trending.Project with the name owner/repository.
+func testProject(owner, repository string, stars int) trending.Project {
+	u, _ := url.Parse("https://github.com/" + owner + "/" + repository)
+	return trending.Project{
+		Name:           owner + "/" + repository,
+		Owner:          owner,
+		RepositoryName: repository,
+		Language:       "Go",
+		Stars:          stars,
+		URL:            u,
+	}
+}
+
+// testStore runs the same tests against every Store implementation.
+func testStore(t *testing.T, s Store) {
+	goDaily := trending.Query{Since: trending.TimeToday, Language: "go"}
+	rustDaily := trending.Query{Since: trending.TimeToday, Language: "rust"}
+
+	// Saved out of order to make sure the result is ordered by FetchedAt
+	snapshots := []*Snapshot{
+		NewProjectsSnapshot(goDaily, []trending.Project{testProject("andygrunwald", "go-trending", 10)}, day(3)),
+		NewProjectsSnapshot(goDaily, []trending.Project{testProject("golang", "go", 100)}, day(1)),
+		NewProjectsSnapshot(rustDaily, []trending.Project{testProject("rust-lang", "rust", 50)}, day(2)),
+		NewDevelopersSnapshot(goDaily, []trending.Developer{{ID: 1, DisplayName: "andygrunwald"}}, day(2)),
+		NewProjectsSnapshot(goDaily, []trending.Project{testProject("golang", "tools", 20)}, day(5)),
+	}
+	for _, snapshot := range snapshots {
+		if err := s.Save(snapshot); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+		if snapshot.ID == 0 {
+			t.Errorf("Save assigned no ID to snapshot %+v", snapshot)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []*Snapshot
+	}{
+		{
+			name:   "all",
+			filter: Filter{},
+			want:   []*Snapshot{snapshots[1], snapshots[2], snapshots[3], snapshots[0], snapshots[4]},
+		},
+		{
+			name:   "go projects",
+			filter: Filter{Kind: KindProjects, Language: "go"},
+			want:   []*Snapshot{snapshots[1], snapshots[0], snapshots[4]},
+		},
+		{
+			name:   "go projects between dates",
+			filter: Filter{Kind: KindProjects, Language: "go", From: day(1), To: day(5)},
+			want:   []*Snapshot{snapshots[1], snapshots[0]},
+		},
+		{
+			name:   "developers",
+			filter: Filter{Kind: KindDevelopers},
+			want:   []*Snapshot{snapshots[3]},
+		},
+		{
+			name:   "weekly",
+			filter: Filter{Since: trending.TimeWeek},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := s.Snapshots(tt.filter)
+		if err != nil {
+			t.Fatalf("%s: Snapshots returned error: %v", tt.name, err)
+		}
+
+		var want []Snapshot
+		for _, w := range tt.want {
+			want = append(want, *w)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Snapshots returned %+v, want %+v", tt.name, got, want)
+		}
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if err := s.Save(snapshots[0]); !errors.Is(err, ErrClosed) {
+		t.Errorf("Save after Close returned %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testStore(t, NewMemory())
+}
+
+func TestBolt(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "trending.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	testStore(t, s)
+}
+
+func TestBolt_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trending.db")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	snapshot := NewProjectsSnapshot(trending.Query{Since: trending.TimeWeek}, []trending.Project{testProject("golang", "go", 100)}, day(1))
+	if err := s.Save(snapshot); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	s.Close()
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer s.Close()
+
+	got, err := s.Snapshots(Filter{})
+	if err != nil {
+		t.Fatalf("Snapshots returned error: %v", err)
+	}
+	if want := []Snapshot{*snapshot}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshots returned %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package trending
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+// Names of the spans created by Trending.
+// Every call like GetProjects creates a root span named "trending." plus the page (like "trending.projects")
+// with the spans below as children.
+const (
+	// SpanGenerateURL covers the generation of the URL of the page.
+	SpanGenerateURL = "trending.generate_url"
+	// SpanFetch covers the HTTP request of the page (or the lookup in the Cache).
+	SpanFetch = "trending.fetch"
+	// SpanParse covers parsing the page into projects, developers or languages.
+	SpanParse = "trending.parse"
+)
+
+// parserStrategy is the strategy used to parse the pages, reported as span attribute.
+const parserStrategy = "goquery"
+
+// Tracer starts the spans around generating the URL, fetching and parsing a page.
+// Set Trending.Tracer to trace requests. The module github.com/andygrunwald/go-trending/otel
+// provides an OpenTelemetry implementation.
+//
+// Spans carry the attributes
+//
+//	trending.query.since, trending.query.language, trending.query.spoken_language, trending.query.sponsorable (root span)
+//	url.full (generate_url, fetch)
+//	http.request.method, http.response.status_code, trending.cache.hit (fetch)
+//	trending.page, trending.parser.strategy, trending.items (parse)
+//
+// Implementations must be safe for concurrent use.
+type Tracer interface {
+	// Start starts the span name as child of the span in ctx.
+	// It returns a context containing the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced operation started by a Tracer.
+type Span interface {
+	// SetAttributes adds attributes to the span.
+	SetAttributes(attributes ...Attribute)
+
+	// RecordError marks the span as failed with err.
+	RecordError(err error)
+
+	// End finishes the span.
+	End()
+}
+
+// Attribute describes a Span. Value is a string, an int or a bool.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// nopSpan is the Span used if no Tracer is configured.
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...Attribute) {}
+func (nopSpan) RecordError(error)          {}
+func (nopSpan) End()                       {}
+
+// startSpan starts the span name with attributes using t.Tracer, if set.
+func (t *Trending) startSpan(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	if t.Tracer == nil {
+		return ctx, nopSpan{}
+	}
+
+	ctx, span := t.Tracer.Start(ctx, name)
+	if len(attributes) > 0 {
+		span.SetAttributes(attributes...)
+	}
+	return ctx, span
+}
+
+// endSpan records err, if any, and finishes span.
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// queryAttributes returns the span attributes of q.
+func queryAttributes(q Query) []Attribute {
+	return []Attribute{
+		{Key: "trending.query.since", Value: q.Since},
+		{Key: "trending.query.language", Value: q.Language},
+		{Key: "trending.query.spoken_language", Value: q.SpokenLanguage},
+		{Key: "trending.query.sponsorable", Value: q.Sponsorable},
+	}
+}
+
+// tracedURL generates the URL for mode and query within a SpanGenerateURL span.
+func (t *Trending) tracedURL(ctx context.Context, mode string, query Query) (*url.URL, error) {
+	_, span := t.startSpan(ctx, SpanGenerateURL)
+	u, err := t.generateURL(mode, query)
+	if err == nil {
+		span.SetAttributes(Attribute{Key: "url.full", Value: u.String()})
+	}
+	endSpan(span, err)
+	return u, err
+}
+
+// parsing measures parsing a single page for Metrics, Tracer and Logger.
+type parsing struct {
+	t     *Trending
+	ctx   context.Context
+	page  string
+	url   *url.URL
+	start time.Time
+	span  Span
+}
+
+// startParse starts measuring parsing page, fetched from u.
+func (t *Trending) startParse(ctx context.Context, page string, u *url.URL) *parsing {
+	_, span := t.startSpan(ctx, SpanParse,
+		Attribute{Key: "trending.page", Value: page},
+		Attribute{Key: "trending.parser.strategy", Value: parserStrategy},
+	)
+	return &parsing{t: t, ctx: ctx, page: page, url: u, start: time.Now(), span: span}
+}
+
+// done finishes measuring with the number of items found on the page.
+// A page without items is logged as warning, because it usually means that the layout of GitHub changed.
+func (p *parsing) done(items int) {
+	if p.t.Metrics != nil {
+		p.t.Metrics.PageParsed(p.page, items, time.Since(p.start))
+	}
+	p.span.SetAttributes(Attribute{Key: "trending.items", Value: items})
+	p.span.End()
+
+	if items == 0 {
+		p.t.log(p.ctx, slog.LevelWarn, "page contains no items, the layout of GitHub may have changed", "page", p.page, "url", p.url.String())
+	} else {
+		p.t.log(p.ctx, slog.LevelDebug, "parsed page", "page", p.page, "items", items, "duration", time.Since(p.start))
+	}
+}
@@ -0,0 +1,127 @@
+package trending
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// recordingTracer is a Tracer recording all spans.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+// recordingSpan is a span recorded by recordingTracer.
+type recordingSpan struct {
+	name       string
+	parent     string
+	attributes map[string]any
+	err        error
+	ended      bool
+}
+
+// spanKey is the context key of the current recordingSpan.
+type spanKey struct{}
+
+func (t *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := &recordingSpan{name: name, attributes: map[string]any{}}
+	if parent, ok := ctx.Value(spanKey{}).(*recordingSpan); ok {
+		s.parent = parent.name
+	}
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func (s *recordingSpan) SetAttributes(attributes ...Attribute) {
+	for _, a := range attributes {
+		s.attributes[a.Key] = a.Value
+	}
+}
+
+func (s *recordingSpan) RecordError(err error) { s.err = err }
+func (s *recordingSpan) End()                  { s.ended = true }
+
+func TestTrending_Tracer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	tracer := &recordingTracer{}
+	client.Tracer = tracer
+
+	ctx, root := tracer.Start(context.Background(), "report")
+	if _, err := client.FetchProjectsContext(ctx, Query{Since: TimeWeek, Language: "go"}); err != nil {
+		t.Fatalf("FetchProjectsContext returned error: %v", err)
+	}
+	root.End()
+
+	var got []string
+	for _, s := range tracer.spans {
+		if !s.ended {
+			t.Errorf("Span %s was not ended", s.name)
+		}
+		got = append(got, s.parent+" > "+s.name)
+	}
+	want := []string{
+		" > report",
+		"report > trending.projects",
+		"trending.projects > trending.generate_url",
+		"trending.projects > trending.fetch",
+		"trending.projects > trending.parse",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Tracer recorded spans %v, want %v", got, want)
+	}
+
+	attributes := tracer.spans[1].attributes
+	if attributes["trending.query.since"] != TimeWeek || attributes["trending.query.language"] != "go" {
+		t.Errorf("Span trending.projects has attributes %v, want the query", attributes)
+	}
+	attributes = tracer.spans[3].attributes
+	if attributes["http.response.status_code"] != http.StatusOK || attributes["url.full"] != server.URL+"/trending?l=go&since=weekly" {
+		t.Errorf("Span trending.fetch has attributes %v, want status and URL", attributes)
+	}
+	attributes = tracer.spans[4].attributes
+	if attributes["trending.items"] != 25 || attributes["trending.parser.strategy"] != "goquery" || attributes["trending.page"] != PageProjects {
+		t.Errorf("Span trending.parse has attributes %v, want items, parser strategy and page", attributes)
+	}
+}
+
+func TestTrending_TracerError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending/developers", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Bad Gateway", http.StatusBadGateway)
+	})
+
+	tracer := &recordingTracer{}
+	client.Tracer = tracer
+
+	if _, err := client.GetDevelopers(TimeToday, ""); err == nil {
+		t.Fatal("GetDevelopers returned no error, want a status error")
+	}
+
+	if len(tracer.spans) != 3 {
+		t.Fatalf("Tracer recorded %d spans, want 3 (root, generate_url, fetch)", len(tracer.spans))
+	}
+	for _, i := range []int{0, 2} {
+		if s := tracer.spans[i]; s.err == nil {
+			t.Errorf("Span %s recorded no error", s.name)
+		}
+	}
+	if got := tracer.spans[2].attributes["http.response.status_code"]; got != http.StatusBadGateway {
+		t.Errorf("Span trending.fetch has status %v, want %d", got, http.StatusBadGateway)
+	}
+}
@@ -1,12 +1,17 @@
 package trending
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -22,6 +27,10 @@ const (
 	// TimeMonth include the complete month
 	TimeMonth = "monthly"
 
+	// LanguageUnknown is the Language.URLName of the "Unknown languages" entry.
+	// It filters for repositories without a detected language.
+	LanguageUnknown = "unknown"
+
 	// Base URL for the github website
 	defaultBaseURL = "https://github.com"
 	// Relative URL for trending repositories
@@ -59,41 +68,104 @@ type Trending struct {
 
 	// Client to use for requests
 	Client *http.Client
+
+	// Cache stores fetched pages to avoid requesting the same page again.
+	// Defaults to nil (no caching). See NewMemoryCache.
+	Cache Cache
+
+	// Metrics receives measurements of fetching and parsing pages.
+	// Defaults to nil (no measurements).
+	Metrics Metrics
+
+	// Tracer creates spans around generating the URL, fetching and parsing pages.
+	// Defaults to nil (no tracing).
+	Tracer Tracer
+
+	// Logger receives requests and status codes (debug), cache hits (debug),
+	// failed requests (warn) as well as parse warnings and signs of a changed page layout (warn).
+	// Defaults to nil (no logging).
+	Logger *slog.Logger
+}
+
+// StatusError is returned if GitHub answers a request with a status code other than 2xx.
+type StatusError struct {
+	// URL is the requested address.
+	URL string
+
+	// StatusCode is the HTTP status code of the response like 404 or 429.
+	StatusCode int
+
+	// RetryAfter is the value of the Retry-After header of the response, if any.
+	RetryAfter string
+}
+
+// Error returns a message like "trending: GET https://github.com/trending/unknown: 404 Not Found".
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("trending: GET %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
 }
 
 // Project reflects a single trending repository.
 // It provides information as printed on the source website https://github.com/trending.
 type Project struct {
 	// Name is the name of the repository including user / organisation like "andygrunwald/go-trending" or "airbnb/javascript".
-	Name string
+	Name string `json:"name"`
 
 	// Owner is the name of the user or organisation. "andygrunwald" in "andygrunwald/go-trending" or "airbnb" in "airbnb/javascript".
-	Owner string
+	Owner string `json:"owner"`
 
 	// RepositoryName is the name of therepository. "go-trending" in "andygrunwald/go-trending" or "javascript" in "airbnb/javascript".
-	RepositoryName string
+	RepositoryName string `json:"repository_name"`
 
 	// Description is the description of the repository like "JavaScript Style Guide" (for "airbnb/javascript").
-	Description string
+	Description string `json:"description"`
 
 	// Language is the determined programing language of the project (by Github).
 	// Sometimes Language is an empty string, because Github can`t determine the (main) programing language (like for "google/deepdream").
-	Language string
+	Language string `json:"language"`
+
+	// LanguageColor is the hex color of Language like "#3572A5" for "Python", as shown on the trending page.
+	// If the page contains no color, the color of the bundled catalog is used (see LookupLanguage).
+	LanguageColor string `json:"language_color,omitempty"`
 
-	// Stars is the number of github stars this project received in the given timeframe (see TimeToday / TimeWeek / TimeMonth constants).
-	// This number don`t reflect the overall stars of the project.
-	Stars int
+	// Stars is the overall number of github stars of the project, as shown on the trending page.
+	Stars int `json:"stars"`
+
+	// PeriodStars is the number of github stars this project received in the given timeframe (see TimeToday / TimeWeek / TimeMonth constants),
+	// like 1582 for "1,582 stars today".
+	PeriodStars int `json:"period_stars"`
 
 	// URL is the http(s) address of the project reflected as url.URL datastructure like "https://github.com/Workiva/go-datastructures".
-	URL *url.URL
+	URL *url.URL `json:"url"`
 
 	// ContributorURL is the http(s) address of the contributors page of the project reflected as url.URL datastructure like "https://github.com/Workiva/go-datastructures/graphs/contributors".
-	ContributorURL *url.URL
+	ContributorURL *url.URL `json:"contributor_url"`
 
 	// Contributor are a collection of Developer.
 	// Be aware that this collection don`t covers all contributor.
 	// Only those who are mentioned at githubs trending page.
-	Contributor []Developer
+	Contributor []Developer `json:"contributors"`
+
+	// Enrichment is additional data of the GitHub API like the license and the topics.
+	// It is nil unless the project was enriched, see package enrich.
+	Enrichment *Enrichment `json:"enrichment,omitempty"`
+}
+
+// Query reflects a request for trending projects or developers.
+// All fields are optional. Empty fields fall back to the defaults of Github.
+type Query struct {
+	// Since is the timeframe of the request. See TimeToday / TimeWeek / TimeMonth constants.
+	Since string `json:"since,omitempty"`
+
+	// Language is the Language.URLName of the programing language like "go" or "c%23".
+	Language string `json:"language,omitempty"`
+
+	// SpokenLanguage is the SpokenLanguage.Code of the spoken language of the repository like "en" or "zh".
+	// Only used for projects.
+	SpokenLanguage string `json:"spoken_language,omitempty"`
+
+	// Sponsorable limits the result to developers who can be sponsored via GitHub Sponsors.
+	// Only used for developers.
+	Sponsorable bool `json:"sponsorable,omitempty"`
 }
 
 // Language reflects a single (programing) language offered by github for filtering.
@@ -101,33 +173,60 @@ type Project struct {
 // For filter input you should use the URLName of Language.
 type Language struct {
 	// Name is the human readable name of the language like "Go" or "Web Ontology Language"
-	Name string
+	Name string `json:"name"`
 
 	// URLName is the machine readable / usable name of the language used for filtering / url parameters like "go" or "web-ontology-language".
 	// Please use URLName if you want to filter your requests.
-	URLName string
+	URLName string `json:"url_name"`
 
 	// URL is the filter URL for the language like "https://github.com/trending?l=go" for "go" or "https://github.com/trending?l=unknown" or "unknown".
-	URL *url.URL
+	URL *url.URL `json:"url"`
+
+	// Color is the hex color of the language like "#00ADD8" for "Go". Empty if GitHub has no color for the language.
+	Color string `json:"color,omitempty"`
+
+	// Type is the linguist type of the language like LanguageTypeProgramming or LanguageTypeMarkup. Empty if unknown.
+	Type string `json:"type,omitempty"`
+
+	// Popular is true for the languages GitHub shows on top of the language dropdown, like "Go" or "Python".
+	Popular bool `json:"popular,omitempty"`
+}
+
+// SpokenLanguage reflects a single spoken language offered by github for filtering.
+// If you call "GetProjectsByQuery" you are able to filter by spoken language.
+// For filter input you should use the Code of SpokenLanguage.
+type SpokenLanguage struct {
+	// Name is the human readable name of the spoken language like "English" or "Chinese".
+	Name string `json:"name"`
+
+	// Code is the ISO 639-1 code of the spoken language used for filtering / url parameters like "en" or "zh".
+	Code string `json:"code"`
+
+	// URL is the filter URL for the spoken language like "https://github.com/trending?spoken_language_code=en".
+	URL *url.URL `json:"url"`
 }
 
 // Developer reflects a single trending developer / organisation.
 // It provides information as printed on the source website https://github.com/trending/developers.
 type Developer struct {
 	// ID is the github`s unique identifier of the user / organisation like 1342004 (google) or 698437 (airbnb).
-	ID int
+	ID int `json:"id"`
 
 	// // DisplayName is the username of the developer / organisation like "torvalds" or "apache".
-	DisplayName string
+	DisplayName string `json:"display_name"`
 
 	// FullName is the real name of the developer / organisation like "Linus Torvalds" (for "torvalds") or "The Apache Software Foundation" (for "apache").
-	FullName string
+	FullName string `json:"full_name"`
 
 	// URL is the http(s) address of the developer / organisation reflected as url.URL datastructure like https://github.com/torvalds.
-	URL *url.URL
+	URL *url.URL `json:"url"`
 
 	// Avatar is the http(s) address of the developer / organisation avatar as url.URL datastructure like https://avatars1.githubusercontent.com/u/1024025?v=3&s=192.
-	Avatar *url.URL
+	Avatar *url.URL `json:"avatar"`
+
+	// Enrichment is additional data of the GitHub API like the company and the number of followers.
+	// It is nil unless the developer was enriched, see package enrich.
+	Enrichment *DeveloperEnrichment `json:"enrichment,omitempty"`
 }
 
 // NewTrending is the main entry point of the trending package.
@@ -163,25 +262,36 @@ func NewTrendingWithClient(client *http.Client) *Trending {
 // Further more it must be the Language.URLName and not the human readable Language.Name.
 // If language is an empty string "All languages" will be applied (current default by Github).
 func (t *Trending) GetProjects(time, language string) ([]Project, error) {
-	var projects []Project
+	return t.GetProjectsByQuery(Query{Since: time, Language: language})
+}
+
+// GetProjectsByQuery provides a slice of Projects filtered by the given query.
+//
+// Next to the timeframe and the programing language (see GetProjects)
+// projects can be filtered by the spoken language of the repository.
+// Query.Sponsorable is ignored for projects.
+func (t *Trending) GetProjectsByQuery(q Query) ([]Project, error) {
+	projects, _, err := t.getProjects(context.Background(), q)
+	return projects, err
+}
+
+// getProjects provides the projects of the given query together with the time the page was fetched.
+func (t *Trending) getProjects(ctx context.Context, q Query) (projects []Project, fetchedAt time.Time, err error) {
+	ctx, span := t.startSpan(ctx, "trending."+PageProjects, queryAttributes(q)...)
+	defer func() { endSpan(span, err) }()
 
 	// Generate the correct URL to call
-	u, err := t.generateURL(modeRepositories, time, language)
+	u, err := t.tracedURL(ctx, modeRepositories, q)
 	if err != nil {
-		return projects, err
+		return projects, fetchedAt, err
 	}
 
 	// Receive document
-	res, err := t.Client.Get(u.String())
+	doc, fetchedAt, err := t.fetch(ctx, PageProjects, u)
 	if err != nil {
-		return projects, err
+		return projects, fetchedAt, err
 	}
-
-	doc, err := goquery.NewDocumentFromReader(res.Body)
-	if err != nil {
-		return projects, err
-	}
-	defer res.Body.Close()
+	parse := t.startParse(ctx, PageProjects, u)
 
 	// Query our information
 	doc.Find(".Box article.Box-row").Each(func(i int, s *goquery.Selection) {
@@ -190,6 +300,12 @@ func (t *Trending) GetProjects(time, language string) ([]Project, error) {
 
 		// Split name (like "andygrunwald/go-trending") into owner ("andygrunwald") and repository name ("go-trending"")
 		splittedName := strings.SplitAfterN(name, "/", 2)
+		if len(splittedName) != 2 {
+			err := fmt.Errorf("invalid name %q of project %d", name, i+1)
+			t.parseFailed(PageProjects, err)
+			t.log(ctx, slog.LevelWarn, "skipping project with invalid name, the layout of GitHub may have changed", "page", PageProjects, "url", u.String(), "error", err)
+			return
+		}
 		owner := splittedName[0][:len(splittedName[0])-1]
 		owner = strings.TrimSpace(owner)
 		repositoryName := strings.TrimSpace(splittedName[1])
@@ -206,15 +322,37 @@ func (t *Trending) GetProjects(time, language string) ([]Project, error) {
 		language := s.Find("span[itemprop=programmingLanguage]").Eq(0).Text()
 		language = strings.TrimSpace(language)
 
+		languageColor := parseLanguageColor(s)
+		if len(languageColor) == 0 && len(language) > 0 {
+			if info, ok := LookupLanguage(language); ok {
+				languageColor = info.Color
+			}
+		}
+
 		starsString := s.Find("div a[href$=\"/stargazers\"]").Text()
 		starsString = strings.TrimSpace(starsString)
 		// Replace english thousand separator ","
 		starsString = strings.Replace(starsString, ",", "", 1)
 		stars, err := strconv.Atoi(starsString)
 		if err != nil {
+			err = fmt.Errorf("stars of %s: %w", name, err)
+			t.parseFailed(PageProjects, err)
+			t.log(ctx, slog.LevelWarn, "cannot parse stars of project", "page", PageProjects, "project", name, "error", err)
 			stars = 0
 		}
 
+		// The stars of the period are shown like "1,582 stars today" or "9,273 stars this week"
+		periodStars := 0
+		if fields := strings.Fields(s.Find("span.float-sm-right").Text()); len(fields) > 0 {
+			periodStars, err = strconv.Atoi(strings.ReplaceAll(fields[0], ",", ""))
+			if err != nil {
+				err = fmt.Errorf("period stars of %s: %w", name, err)
+				t.parseFailed(PageProjects, err)
+				t.log(ctx, slog.LevelWarn, "cannot parse period stars of project", "page", PageProjects, "project", name, "error", err)
+				periodStars = 0
+			}
+		}
+
 		contributorSelection := s.Find("div.f6 a").Eq(2)
 		contributorPath, exists := contributorSelection.Attr("href")
 		contributorURL := t.appendBaseHostToPath(contributorPath, exists)
@@ -237,49 +375,92 @@ func (t *Trending) GetProjects(time, language string) ([]Project, error) {
 			RepositoryName: repositoryName,
 			Description:    description,
 			Language:       language,
+			LanguageColor:  languageColor,
 			Stars:          stars,
+			PeriodStars:    periodStars,
 			URL:            projectURL,
 			ContributorURL: contributorURL,
 			Contributor:    developer,
 		}
 		projects = append(projects, p)
 	})
+	parse.done(len(projects))
+	if t.Metrics != nil {
+		t.Metrics.ProjectsFetched(q, projects)
+	}
 
-	return projects, nil
+	return projects, fetchedAt, nil
 }
 
 // GetLanguages will return a slice of Language known by gitub.
 // With the Language.URLName you can filter your GetProjects / GetDevelopers calls.
+//
+// Every language is returned once. The "Unknown languages" entry (URLName LanguageUnknown) is always the first one.
+// Popular languages are flagged with Language.Popular, see GetPopularLanguages.
 func (t *Trending) GetLanguages() ([]Language, error) {
-	return t.generateLanguages("#languages-menuitems a.select-menu-item")
+	return t.generateLanguages(context.Background(), PageLanguages, "#languages-menuitems a.select-menu-item, #languages-menuitems .select-menu-divider")
+}
+
+// GetPopularLanguages will return the popular languages of GetLanguages, like "Go", "Python" or "TypeScript".
+// GitHub shows them on top of the language dropdown.
+func (t *Trending) GetPopularLanguages() ([]Language, error) {
+	languages, err := t.GetLanguages()
+	if err != nil {
+		return nil, err
+	}
+
+	var popular []Language
+	for _, l := range languages {
+		if l.Popular {
+			popular = append(popular, l)
+		}
+	}
+	return popular, nil
 }
 
 // generateLanguages will retrieve the languages out of the github document.
-// Trending languages are shown on the right side as a small list.
-// Other languages are hidden in a dropdown at this site
-func (t *Trending) generateLanguages(mainSelector string) ([]Language, error) {
-	var languages []Language
+// Popular languages can be shown as a short list on top of the dropdown, separated by a divider.
+// All languages (including the popular ones again) are listed in the dropdown.
+// If the page has no such list, Language.Popular is taken from the bundled catalog.
+func (t *Trending) generateLanguages(ctx context.Context, page, mainSelector string) (languages []Language, err error) {
+	ctx, span := t.startSpan(ctx, "trending."+page)
+	defer func() { endSpan(span, err) }()
 
 	// Generate the URL to call
-	u, err := t.generateURL(modeLanguages, "", "")
+	u, err := t.tracedURL(ctx, modeLanguages, Query{})
 	if err != nil {
 		return languages, err
 	}
 
 	// Get document
-	res, err := t.Client.Get(u.String())
+	doc, _, err := t.fetch(ctx, page, u)
 	if err != nil {
 		return languages, err
 	}
+	parse := t.startParse(ctx, page, u)
 
-	doc, err := goquery.NewDocumentFromReader(res.Body)
-	if err != nil {
-		return languages, err
-	}
-	defer res.Body.Close()
+	// The languages page is the trending page, so the colors of the listed projects are current colors of GitHub
+	pageColors := make(map[string]string)
+	doc.Find(".Box article.Box-row").Each(func(i int, s *goquery.Selection) {
+		name := strings.TrimSpace(s.Find("span[itemprop=programmingLanguage]").Eq(0).Text())
+		if color := parseLanguageColor(s); len(name) > 0 && len(color) > 0 {
+			pageColors[name] = color
+		}
+	})
+
+	// Languages listed before a divider are the popular ones
+	selection := doc.Find(mainSelector)
+	pageGroupsPopular := selection.Filter(".select-menu-divider").Length() > 0
+	popularSection := pageGroupsPopular
 
 	// Query our information
-	doc.Find(mainSelector).Each(func(i int, s *goquery.Selection) {
+	seen := make(map[string]bool)
+	selection.Each(func(i int, s *goquery.Selection) {
+		if s.HasClass("select-menu-divider") {
+			popularSection = false
+			return
+		}
+
 		expectedPrefix := "https://github.com"
 		languageAddress, _ := s.Attr("href")
 		if !strings.HasPrefix(languageAddress, expectedPrefix) {
@@ -295,15 +476,97 @@ func (t *Trending) generateLanguages(mainSelector string) ([]Language, error) {
 			languageURLName = matches[1]
 		}
 
+		// Renamed languages are listed with their old and new name, popular ones in both lists
+		if seen[languageURLName] {
+			return
+		}
+		seen[languageURLName] = true
+
 		language := Language{
 			Name:    strings.TrimSpace(s.Text()),
 			URLName: languageURLName,
 			URL:     filterURL,
+			Popular: popularSection && languageURLName != LanguageUnknown,
+		}
+		if info, ok := lookupCatalog(languageURLName); ok {
+			language.Color = info.Color
+			language.Type = info.Type
+			if !pageGroupsPopular {
+				language.Popular = info.Popular
+			}
+		}
+		if color, ok := pageColors[language.Name]; ok {
+			language.Color = color
 		}
 		languages = append(languages, language)
 	})
+	parse.done(len(languages))
 
-	return languages, nil
+	return withUnknownLanguageFirst(languages), nil
+}
+
+// withUnknownLanguageFirst moves the "Unknown languages" entry to the front of languages.
+// If languages has no such entry (but other languages), it is added.
+func withUnknownLanguageFirst(languages []Language) []Language {
+	if len(languages) == 0 {
+		return languages
+	}
+
+	unknown := Language{
+		Name:    "Unknown languages",
+		URLName: LanguageUnknown,
+	}
+	unknown.URL, _ = url.Parse("https://github.com/trending/" + LanguageUnknown)
+
+	result := make([]Language, 1, len(languages)+1)
+	for _, l := range languages {
+		if l.URLName == LanguageUnknown {
+			unknown = l
+			continue
+		}
+		result = append(result, l)
+	}
+	result[0] = unknown
+	return result
+}
+
+// GetSpokenLanguages will return a slice of SpokenLanguage known by github.
+// With the SpokenLanguage.Code you can filter your GetProjectsByQuery calls.
+func (t *Trending) GetSpokenLanguages() (spokenLanguages []SpokenLanguage, err error) {
+	ctx, span := t.startSpan(context.Background(), "trending."+PageSpokenLanguages)
+	defer func() { endSpan(span, err) }()
+
+	// Generate the URL to call
+	u, err := t.tracedURL(ctx, modeLanguages, Query{})
+	if err != nil {
+		return spokenLanguages, err
+	}
+
+	// Get document
+	doc, _, err := t.fetch(ctx, PageSpokenLanguages, u)
+	if err != nil {
+		return spokenLanguages, err
+	}
+	parse := t.startParse(ctx, PageSpokenLanguages, u)
+
+	// Query our information
+	doc.Find("#select-menu-spoken-language a.select-menu-item").Each(func(i int, s *goquery.Selection) {
+		address, exists := s.Attr("href")
+		filterURL := t.appendBaseHostToPath(address, exists)
+		if filterURL == nil {
+			return
+		}
+
+		spokenLanguage := SpokenLanguage{
+			Name: strings.TrimSpace(s.Text()),
+			Code: filterURL.Query().Get("spoken_language_code"),
+			URL:  filterURL,
+		}
+		spokenLanguages = append(spokenLanguages, spokenLanguage)
+	})
+	parse.done(len(spokenLanguages))
+
+	return spokenLanguages, nil
 }
 
 // GetDevelopers provides a slice of Developer filtered by the given time and language.
@@ -316,25 +579,36 @@ func (t *Trending) generateLanguages(mainSelector string) ([]Language, error) {
 // Further more it must be the Language.URLName and not the human readable Language.Name.
 // If language is an empty string "All languages" will be applied (current default by Github).
 func (t *Trending) GetDevelopers(time, language string) ([]Developer, error) {
-	var developers []Developer
+	return t.GetDevelopersByQuery(Query{Since: time, Language: language})
+}
+
+// GetDevelopersByQuery provides a slice of Developer filtered by the given query.
+//
+// Next to the timeframe and the programing language (see GetDevelopers)
+// developers can be limited to those who are sponsorable.
+// Query.SpokenLanguage is ignored for developers.
+func (t *Trending) GetDevelopersByQuery(q Query) ([]Developer, error) {
+	developers, _, err := t.getDevelopers(context.Background(), q)
+	return developers, err
+}
+
+// getDevelopers provides the developers of the given query together with the time the page was fetched.
+func (t *Trending) getDevelopers(ctx context.Context, q Query) (developers []Developer, fetchedAt time.Time, err error) {
+	ctx, span := t.startSpan(ctx, "trending."+PageDevelopers, queryAttributes(q)...)
+	defer func() { endSpan(span, err) }()
 
 	// Generate URL
-	u, err := t.generateURL(modeDevelopers, time, language)
+	u, err := t.tracedURL(ctx, modeDevelopers, q)
 	if err != nil {
-		return developers, err
+		return developers, fetchedAt, err
 	}
 
 	// Get document
-	res, err := t.Client.Get(u.String())
+	doc, fetchedAt, err := t.fetch(ctx, PageDevelopers, u)
 	if err != nil {
-		return developers, err
+		return developers, fetchedAt, err
 	}
-
-	doc, err := goquery.NewDocumentFromReader(res.Body)
-	if err != nil {
-		return developers, err
-	}
-	defer res.Body.Close()
+	parse := t.startParse(ctx, PageDevelopers, u)
 
 	// Query information
 	doc.Find("main .Box div article[id^=\"pa-\"]").Each(func(i int, s *goquery.Selection) {
@@ -354,8 +628,80 @@ func (t *Trending) GetDevelopers(time, language string) ([]Developer, error) {
 
 		developers = append(developers, t.newDeveloper(name, fullName, linkURL, avatarURL))
 	})
+	parse.done(len(developers))
 
-	return developers, nil
+	return developers, fetchedAt, nil
+}
+
+// fetch requests u, the address of page, and returns it as document together with the time it was fetched.
+// If a Cache is configured, the page is served from the cache if possible.
+func (t *Trending) fetch(ctx context.Context, page string, u *url.URL) (doc *goquery.Document, fetchedAt time.Time, err error) {
+	key := u.String()
+	ctx, span := t.startSpan(ctx, SpanFetch,
+		Attribute{Key: "url.full", Value: key},
+		Attribute{Key: "http.request.method", Value: http.MethodGet},
+	)
+	defer func() { endSpan(span, err) }()
+
+	if t.Cache != nil {
+		entry, ok := t.Cache.Get(key)
+		t.cacheLookup(page, ok)
+		span.SetAttributes(Attribute{Key: "trending.cache.hit", Value: ok})
+		if ok {
+			t.log(ctx, slog.LevelDebug, "serving page from cache", "page", page, "url", key, "fetched_at", entry.FetchedAt)
+			doc, err = goquery.NewDocumentFromReader(bytes.NewReader(entry.Body))
+			if err != nil {
+				t.parseFailed(page, err)
+			}
+			return doc, entry.FetchedAt, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, fetchedAt, err
+	}
+
+	t.log(ctx, slog.LevelDebug, "requesting page", "page", page, "url", key)
+	start := time.Now()
+	res, err := t.Client.Do(req)
+	if err != nil {
+		t.requestDone(page, 0, start)
+		t.log(ctx, slog.LevelWarn, "request failed", "page", page, "url", key, "duration", time.Since(start), "error", err)
+		return nil, fetchedAt, err
+	}
+	defer res.Body.Close()
+	span.SetAttributes(Attribute{Key: "http.response.status_code", Value: res.StatusCode})
+	t.log(ctx, slog.LevelDebug, "received response", "page", page, "url", key, "status", res.StatusCode, "duration", time.Since(start))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		t.requestDone(page, res.StatusCode, start)
+		t.log(ctx, slog.LevelWarn, "unexpected status code", "page", page, "url", key, "status", res.StatusCode, "retry_after", res.Header.Get("Retry-After"))
+		return nil, fetchedAt, &StatusError{
+			URL:        key,
+			StatusCode: res.StatusCode,
+			RetryAfter: res.Header.Get("Retry-After"),
+		}
+	}
+
+	body, err := io.ReadAll(res.Body)
+	t.requestDone(page, res.StatusCode, start)
+	if err != nil {
+		return nil, fetchedAt, err
+	}
+	fetchedAt = time.Now()
+
+	doc, err = goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		t.parseFailed(page, err)
+		t.log(ctx, slog.LevelWarn, "cannot parse page", "page", page, "url", key, "error", err)
+		return nil, time.Time{}, err
+	}
+
+	if t.Cache != nil {
+		t.Cache.Set(key, CacheEntry{Body: body, FetchedAt: fetchedAt})
+	}
+	return doc, fetchedAt, nil
 }
 
 // newDeveloper is a utility function to create a new Developer
@@ -369,6 +715,19 @@ func (t *Trending) newDeveloper(name, fullName string, linkURL, avatarURL *url.U
 	}
 }
 
+// parseLanguageColor returns the hex color of the language dot of the project row s like "#3572A5".
+// The color is part of the inline style like "background-color: #3572A5". If there is no color, an empty string is returned.
+func parseLanguageColor(s *goquery.Selection) string {
+	style, _ := s.Find("span.repo-language-color").First().Attr("style")
+	for _, declaration := range strings.Split(style, ";") {
+		property, value, found := strings.Cut(declaration, ":")
+		if found && strings.TrimSpace(property) == "background-color" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
 // trimBraces will remove braces "(" & ")" from the string
 func (t *Trending) trimBraces(text string) string {
 	text = strings.TrimSpace(text)
@@ -441,8 +800,8 @@ func (t *Trending) getProjectName(name string) string {
 
 // generateURL will generate the correct URL to call the github site.
 //
-// Depending on mode, time and language it will set the correct pathes and query parameters.
-func (t *Trending) generateURL(mode, time, language string) (*url.URL, error) {
+// Depending on mode and query it will set the correct pathes and query parameters.
+func (t *Trending) generateURL(mode string, query Query) (*url.URL, error) {
 	urlStr := urlTrendingPath
 	if mode == modeDevelopers {
 		urlStr += urlDevelopersPath
@@ -450,12 +809,20 @@ func (t *Trending) generateURL(mode, time, language string) (*url.URL, error) {
 
 	u := t.appendBaseHostToPath(urlStr, true)
 	q := u.Query()
-	if len(time) > 0 {
-		q.Set("since", time)
+	if len(query.Since) > 0 {
+		q.Set("since", query.Since)
+	}
+
+	if len(query.Language) > 0 {
+		q.Set("l", query.Language)
+	}
+
+	if len(query.SpokenLanguage) > 0 && mode == modeRepositories {
+		q.Set("spoken_language_code", query.SpokenLanguage)
 	}
 
-	if len(language) > 0 {
-		q.Set("l", language)
+	if query.Sponsorable && mode == modeDevelopers {
+		q.Set("sponsorable", "1")
 	}
 
 	u.RawQuery = q.Encode()
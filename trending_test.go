@@ -1,6 +1,7 @@
 package trending
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -240,6 +241,116 @@ func TestGetLanguages_CorrectContent(t *testing.T) {
 	if languages[1].URL.String() != secondLanguageURL {
 		t.Errorf("GetLanguages returned %+v, want %+v", languages[1].URL.String(), secondLanguageURL)
 	}
+
+	for _, l := range languages {
+		if l.URLName == "go" && (l.Color != "#00ADD8" || l.Type != LanguageTypeProgramming) {
+			t.Errorf("GetLanguages returned color %q and type %q for Go, want %q and %q", l.Color, l.Type, "#00ADD8", LanguageTypeProgramming)
+		}
+	}
+}
+
+func TestGetLanguages_Unique(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	languages, err := client.GetLanguages()
+	if err != nil {
+		t.Fatalf("GetLanguages returned error: %v", err)
+	}
+
+	// The page lists renamed languages like "Visual Basic .NET" twice
+	seen := make(map[string]bool)
+	for _, l := range languages {
+		if seen[l.URLName] {
+			t.Errorf("GetLanguages returned %q twice", l.URLName)
+		}
+		seen[l.URLName] = true
+	}
+
+	if languages[0].URLName != LanguageUnknown || languages[0].Popular {
+		t.Errorf("GetLanguages returned %+v as first language, want the unknown languages entry", languages[0])
+	}
+}
+
+func TestGetPopularLanguages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	// The page has no popular list, so the catalog decides
+	languages, err := client.GetPopularLanguages()
+	if err != nil {
+		t.Fatalf("GetPopularLanguages returned error: %v", err)
+	}
+	if len(languages) < 20 || len(languages) > 30 {
+		t.Errorf("GetPopularLanguages returned %d languages, want between 20 and 30", len(languages))
+	}
+	found := false
+	for _, l := range languages {
+		found = found || l.URLName == "go"
+	}
+	if !found {
+		t.Errorf("GetPopularLanguages returned %v, want Go to be included", languages)
+	}
+}
+
+func TestGetPopularLanguages_PageGroups(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<div id="languages-menuitems">
+			<a class="select-menu-item" href="/trending/unknown?since=daily">Unknown languages</a>
+			<a class="select-menu-item" href="/trending/zig?since=daily">Zig</a>
+			<div class="select-menu-divider">Other languages</div>
+			<a class="select-menu-item" href="/trending/go?since=daily">Go</a>
+			<a class="select-menu-item" href="/trending/unknown?since=daily">Unknown languages</a>
+			<a class="select-menu-item" href="/trending/zig?since=daily">Zig</a>
+		</div>`)
+	})
+
+	languages, err := client.GetLanguages()
+	if err != nil {
+		t.Fatalf("GetLanguages returned error: %v", err)
+	}
+	var got []string
+	for _, l := range languages {
+		got = append(got, fmt.Sprintf("%s:%v", l.URLName, l.Popular))
+	}
+	want := []string{"unknown:false", "zig:true", "go:false"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetLanguages returned %v, want %v", got, want)
+	}
+}
+
+func TestGetProjects_LanguageColorFallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<div class="Box"><article class="Box-row">
+			<h2><a href="/a/b">a / b</a></h2>
+			<span itemprop="programmingLanguage">Go</span>
+			<div><a href="/a/b/stargazers">1</a></div>
+		</article></div>`)
+	})
+
+	projects, err := client.GetProjects(TimeToday, "")
+	if err != nil {
+		t.Fatalf("GetProjects returned error: %v", err)
+	}
+	if len(projects) != 1 || projects[0].LanguageColor != "#00ADD8" {
+		t.Errorf("GetProjects returned %+v, want the catalog color %q for Go", projects, "#00ADD8")
+	}
 }
 
 func TestGetLanguages_NoContent(t *testing.T) {
@@ -345,9 +456,15 @@ func TestGetProjects_CorrectContent(t *testing.T) {
 	if len(p.Language) == 0 {
 		t.Error("GetProjects returns an empty language.")
 	}
+	if p.Language == "Python" && p.LanguageColor != "#3572A5" {
+		t.Errorf("GetProjects returned language color %q for Python, want %q", p.LanguageColor, "#3572A5")
+	}
 	if p.Stars == 0 {
 		t.Error("GetProjects returns a trending project without stars.")
 	}
+	if p.Name == "smol-ai/developer" && (p.Stars != 5096 || p.PeriodStars != 1582) {
+		t.Errorf("GetProjects returned %d stars and %d period stars for %s, want 5096 and 1582", p.Stars, p.PeriodStars, p.Name)
+	}
 	if len(p.URL.String()) == 0 {
 		t.Error("GetProjects returns an empty project URL.")
 	}
@@ -383,3 +500,154 @@ func TestGetProjects_WithoutWhitespaceInName(t *testing.T) {
 		t.Errorf("Project name %s contains whitespace, expected no whitespace in project name.", p.Name)
 	}
 }
+
+func TestGetProjectsByQuery_SpokenLanguage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"since":                "weekly",
+			"l":                    "go",
+			"spoken_language_code": "en",
+		})
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	projects, err := client.GetProjectsByQuery(Query{Since: TimeWeek, Language: "go", SpokenLanguage: "en", Sponsorable: true})
+	if err != nil {
+		t.Errorf("GetProjectsByQuery returned error: %v", err)
+	}
+
+	if len(projects) == 0 {
+		t.Error("GetProjectsByQuery returned no projects at all")
+	}
+}
+
+func TestGetDevelopersByQuery_Sponsorable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending/developers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"since":       "monthly",
+			"sponsorable": "1",
+		})
+		website := getContentOfFile("./testdata/github.com_trending_developers.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	developers, err := client.GetDevelopersByQuery(Query{Since: TimeMonth, SpokenLanguage: "en", Sponsorable: true})
+	if err != nil {
+		t.Errorf("GetDevelopersByQuery returned error: %v", err)
+	}
+
+	if len(developers) == 0 {
+		t.Error("GetDevelopersByQuery returned no developers at all")
+	}
+}
+
+func TestGetSpokenLanguages_CorrectContent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	spokenLanguages, err := client.GetSpokenLanguages()
+	if err != nil {
+		t.Errorf("GetSpokenLanguages returned error: %v", err)
+	}
+
+	// Today (2026-10-18), we have ~180 spoken languages
+	if len(spokenLanguages) <= 100 {
+		t.Fatalf("GetSpokenLanguages returned %+v spoken languages, expected > 100", len(spokenLanguages))
+	}
+
+	first := spokenLanguages[0]
+	if first.Name != "Abkhazian" || first.Code != "ab" {
+		t.Errorf("GetSpokenLanguages returned %+v, want Abkhazian (ab)", first)
+	}
+
+	firstURL := server.URL + "/trending?spoken_language_code=ab"
+	if first.URL.String() != firstURL {
+		t.Errorf("GetSpokenLanguages returned %+v, want %+v", first.URL.String(), firstURL)
+	}
+}
+
+func TestFetchProjects(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"since": "weekly",
+			"l":     "go",
+		})
+		website := getContentOfFile("./testdata/github.com_trending.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	q := Query{Since: TimeWeek, Language: "go"}
+	result, err := client.FetchProjects(q)
+	if err != nil {
+		t.Fatalf("FetchProjects returned error: %v", err)
+	}
+
+	if result.Query != q {
+		t.Errorf("FetchProjects returned query %+v, want %+v", result.Query, q)
+	}
+	if result.FetchedAt.IsZero() {
+		t.Error("FetchProjects returned no fetch time")
+	}
+	if len(result.Projects) != 25 {
+		t.Errorf("FetchProjects returned %d projects, want 25", len(result.Projects))
+	}
+}
+
+func TestFetchDevelopers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending/developers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		website := getContentOfFile("./testdata/github.com_trending_developers.html")
+		fmt.Fprint(w, string(website))
+	})
+
+	result, err := client.FetchDevelopers(Query{Since: TimeMonth})
+	if err != nil {
+		t.Fatalf("FetchDevelopers returned error: %v", err)
+	}
+
+	if result.Query.Since != TimeMonth || result.FetchedAt.IsZero() || len(result.Developers) != 25 {
+		t.Errorf("FetchDevelopers returned %+v, want 25 monthly developers with fetch time", result)
+	}
+}
+
+func TestGetProjects_StatusError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+	})
+
+	_, err := client.GetProjects(TimeToday, "")
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("GetProjects returned error %v, want *StatusError", err)
+	}
+	if statusErr.StatusCode != http.StatusTooManyRequests || statusErr.RetryAfter != "60" {
+		t.Errorf("GetProjects returned %+v, want status %d and Retry-After 60", statusErr, http.StatusTooManyRequests)
+	}
+}
@@ -0,0 +1,429 @@
+// Package watch polls the trending pages periodically and emits events when they change.
+//
+// A Watcher fetches the projects or developers of every Target, compares the result with
+// the previous one (see package diff) and emits an Event for every change:
+//
+//	w := watch.New(trending.NewTrending(), []watch.Target{
+//		{Query: trending.Query{Since: trending.TimeToday, Language: "go"}},
+//		{Query: trending.Query{Since: trending.TimeToday}, Developers: true},
+//	}, watch.Options{Interval: 30 * time.Minute})
+//
+//	err := w.Run(ctx, func(e watch.Event) {
+//		fmt.Println(e.Type, e.Name())
+//	})
+//
+// The first poll of a target establishes the baseline and emits no events, except WatchlistMatched (see Options.Watchlist).
+// Run blocks until the context is canceled.
+package watch
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/diff"
+	"github.com/andygrunwald/go-trending/watchlist"
+)
+
+// EventType is the type of a change on the trending page.
+type EventType string
+
+// Types of events.
+const (
+	// Entered is emitted when a project or developer appears on the trending page.
+	Entered EventType = "entered"
+	// Left is emitted when a project or developer falls off the trending page.
+	Left EventType = "left"
+	// RankChanged is emitted when the rank of a project or developer changes.
+	RankChanged EventType = "rank_changed"
+	// StarsJumped is emitted when the stars of a project increased by at least Options.StarsThreshold.
+	StarsJumped EventType = "stars_jumped"
+	// WatchlistMatched is emitted when a project or developer matching Options.Watchlist appears on the trending page.
+	// Unlike the other events, it is emitted by the first poll of a target as well.
+	WatchlistMatched EventType = "watchlist_matched"
+)
+
+// Kinds of targets and events.
+const (
+	// KindProjects are events about trending projects.
+	KindProjects = "projects"
+	// KindDevelopers are events about trending developers.
+	KindDevelopers = "developers"
+)
+
+// Defaults of Options.
+const (
+	// DefaultInterval is the time between two polls.
+	DefaultInterval = 15 * time.Minute
+	// DefaultStarsThreshold is the minimum increase of stars between two polls for StarsJumped.
+	DefaultStarsThreshold = 100
+)
+
+// MaxJitter is the maximum of Options.Jitter. The interval never drops below a tenth of Options.Interval.
+const MaxJitter = 0.9
+
+// Target is a single query that is watched.
+type Target struct {
+	// Query is the query of the trending page.
+	Query trending.Query `json:"query"`
+
+	// Developers watches trending developers instead of projects.
+	Developers bool `json:"developers,omitempty"`
+}
+
+// Kind returns KindProjects or KindDevelopers.
+func (t Target) Kind() string {
+	if t.Developers {
+		return KindDevelopers
+	}
+	return KindProjects
+}
+
+// Event is a single change on the trending page.
+// Ranks are 1-based. A rank of 0 means the project or developer is not part of the result.
+type Event struct {
+	// Type is the type of the change.
+	Type EventType `json:"type"`
+
+	// Kind is KindProjects or KindDevelopers.
+	Kind string `json:"kind"`
+
+	// Query is the query of the trending page that changed.
+	Query trending.Query `json:"query"`
+
+	// Time is the time of the poll that detected the change.
+	Time time.Time `json:"time"`
+
+	// Project is the changed project, if Kind is KindProjects.
+	Project *trending.Project `json:"project,omitempty"`
+
+	// Developer is the changed developer, if Kind is KindDevelopers.
+	Developer *trending.Developer `json:"developer,omitempty"`
+
+	// OldRank is the rank before the change.
+	OldRank int `json:"old_rank"`
+
+	// NewRank is the rank after the change.
+	NewRank int `json:"new_rank"`
+
+	// OldStars are the stars of the project before the change.
+	OldStars int `json:"old_stars,omitempty"`
+
+	// NewStars are the stars of the project after the change.
+	NewStars int `json:"new_stars,omitempty"`
+
+	// Match is the matched entry of the watchlist, if Type is WatchlistMatched.
+	Match *watchlist.Match `json:"match,omitempty"`
+}
+
+// Name returns the name of the project or the display name of the developer of the event.
+func (e Event) Name() string {
+	switch {
+	case e.Project != nil:
+		return e.Project.Name
+	case e.Developer != nil:
+		return e.Developer.DisplayName
+	}
+	return ""
+}
+
+// Options configure a Watcher.
+type Options struct {
+	// Interval is the time between two polls. Defaults to DefaultInterval.
+	Interval time.Duration
+
+	// Jitter randomizes the interval by up to the given fraction, like 0.1 for ±10%.
+	// This avoids that many watchers hit GitHub at the same time.
+	// Negative values disable the jitter, values above MaxJitter are reduced to MaxJitter.
+	Jitter float64
+
+	// StarsThreshold is the minimum increase of stars between two polls that emits StarsJumped.
+	// Defaults to DefaultStarsThreshold. A negative value disables StarsJumped.
+	StarsThreshold int
+
+	// Watchlist emits WatchlistMatched for every match of a project or developer that is new on the trending page.
+	// If nil, no WatchlistMatched events are emitted.
+	Watchlist *watchlist.Watchlist
+
+	// ErrorHandler is called if a target can not be fetched. The watcher continues with the next poll.
+	// If nil, errors are ignored.
+	ErrorHandler func(t Target, err error)
+}
+
+// Watcher polls the trending pages of its targets and emits change events.
+type Watcher struct {
+	client  *trending.Trending
+	targets []Target
+	opts    Options
+
+	mu         sync.Mutex
+	projects   map[Target][]trending.Project
+	developers map[Target][]trending.Developer
+}
+
+// New returns a Watcher that polls targets with client.
+func New(client *trending.Trending, targets []Target, opts Options) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.StarsThreshold == 0 {
+		opts.StarsThreshold = DefaultStarsThreshold
+	}
+	// A jitter of 1 or more could shrink the interval to zero and poll GitHub in a tight loop
+	opts.Jitter = min(max(opts.Jitter, 0), MaxJitter)
+
+	return &Watcher{
+		client:     client,
+		targets:    targets,
+		opts:       opts,
+		projects:   make(map[Target][]trending.Project),
+		developers: make(map[Target][]trending.Developer),
+	}
+}
+
+// Run polls all targets until ctx is canceled and calls handle for every event.
+// handle is called from the goroutine of Run, one event after the other.
+// Run returns the error of the context.
+func (w *Watcher) Run(ctx context.Context, handle func(Event)) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		for _, e := range w.Poll(ctx) {
+			handle(e)
+		}
+		timer.Reset(w.nextInterval())
+	}
+}
+
+// Events polls all targets in a new goroutine until ctx is canceled and sends every event to the returned channel.
+// The channel is closed after ctx is canceled. The caller has to receive all events, otherwise polling blocks.
+func (w *Watcher) Events(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		w.Run(ctx, func(e Event) {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return events
+}
+
+// Poll fetches all targets once and returns the events compared to the previous poll.
+// Run calls Poll periodically. It is exported for callers that want to schedule polls themselves.
+func (w *Watcher) Poll(ctx context.Context) []Event {
+	var events []Event
+	for _, t := range w.targets {
+		if ctx.Err() != nil {
+			break
+		}
+
+		var err error
+		var targetEvents []Event
+		if t.Developers {
+			targetEvents, err = w.pollDevelopers(ctx, t)
+		} else {
+			targetEvents, err = w.pollProjects(ctx, t)
+		}
+		if err != nil {
+			if w.opts.ErrorHandler != nil {
+				w.opts.ErrorHandler(t, err)
+			}
+			continue
+		}
+		events = append(events, targetEvents...)
+	}
+	return events
+}
+
+// pollProjects fetches the projects of t and returns the events compared to the previous poll.
+func (w *Watcher) pollProjects(ctx context.Context, t Target) ([]Event, error) {
+	r, err := w.client.FetchProjectsContext(ctx, t.Query)
+	if err != nil {
+		return nil, err
+	}
+	return w.compareProjects(t, r.Projects, time.Now()), nil
+}
+
+// compareProjects remembers projects as latest result of t and returns the events compared to the previous result.
+func (w *Watcher) compareProjects(t Target, projects []trending.Project, now time.Time) []Event {
+	w.mu.Lock()
+	previous, ok := w.projects[t]
+	w.projects[t] = projects
+	w.mu.Unlock()
+
+	matches := w.matchProjects(t, previous, projects, now)
+	if !ok {
+		return matches
+	}
+
+	d := diff.Projects(previous, projects)
+	newEvent := func(typ EventType, c diff.ProjectChange) Event {
+		p := c.Project
+		return Event{
+			Type:     typ,
+			Kind:     KindProjects,
+			Query:    t.Query,
+			Time:     now,
+			Project:  &p,
+			OldRank:  c.OldRank,
+			NewRank:  c.NewRank,
+			OldStars: c.OldStars,
+			NewStars: c.NewStars,
+		}
+	}
+
+	var events []Event
+	for _, c := range d.Entered {
+		events = append(events, newEvent(Entered, c))
+	}
+	for _, c := range d.Left {
+		events = append(events, newEvent(Left, c))
+	}
+	for _, c := range append(d.MovedUp, d.MovedDown...) {
+		events = append(events, newEvent(RankChanged, c))
+	}
+	if w.opts.StarsThreshold > 0 {
+		for _, list := range [][]diff.ProjectChange{d.MovedUp, d.MovedDown, d.Unchanged} {
+			for _, c := range list {
+				if c.StarsDelta() >= w.opts.StarsThreshold {
+					events = append(events, newEvent(StarsJumped, c))
+				}
+			}
+		}
+	}
+	return append(events, matches...)
+}
+
+// matchProjects returns a WatchlistMatched event for every watchlist match of projects that isn't part of previous.
+func (w *Watcher) matchProjects(t Target, previous, projects []trending.Project, now time.Time) []Event {
+	if w.opts.Watchlist == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(previous))
+	for _, p := range previous {
+		seen[strings.ToLower(p.Name)] = true
+	}
+
+	var events []Event
+	for _, m := range w.opts.Watchlist.MatchProjects(t.Query, projects) {
+		if seen[strings.ToLower(m.Project.Name)] {
+			continue
+		}
+		m := m
+		events = append(events, Event{
+			Type:     WatchlistMatched,
+			Kind:     KindProjects,
+			Query:    t.Query,
+			Time:     now,
+			Project:  m.Project,
+			NewRank:  m.Rank,
+			NewStars: m.Project.Stars,
+			Match:    &m,
+		})
+	}
+	return events
+}
+
+// pollDevelopers fetches the developers of t and returns the events compared to the previous poll.
+func (w *Watcher) pollDevelopers(ctx context.Context, t Target) ([]Event, error) {
+	r, err := w.client.FetchDevelopersContext(ctx, t.Query)
+	if err != nil {
+		return nil, err
+	}
+	return w.compareDevelopers(t, r.Developers, time.Now()), nil
+}
+
+// compareDevelopers remembers developers as latest result of t and returns the events compared to the previous result.
+func (w *Watcher) compareDevelopers(t Target, developers []trending.Developer, now time.Time) []Event {
+	w.mu.Lock()
+	previous, ok := w.developers[t]
+	w.developers[t] = developers
+	w.mu.Unlock()
+
+	matches := w.matchDevelopers(t, previous, developers, now)
+	if !ok {
+		return matches
+	}
+
+	d := diff.Developers(previous, developers)
+	newEvent := func(typ EventType, c diff.DeveloperChange) Event {
+		dev := c.Developer
+		return Event{
+			Type:      typ,
+			Kind:      KindDevelopers,
+			Query:     t.Query,
+			Time:      now,
+			Developer: &dev,
+			OldRank:   c.OldRank,
+			NewRank:   c.NewRank,
+		}
+	}
+
+	var events []Event
+	for _, c := range d.Entered {
+		events = append(events, newEvent(Entered, c))
+	}
+	for _, c := range d.Left {
+		events = append(events, newEvent(Left, c))
+	}
+	for _, c := range append(d.MovedUp, d.MovedDown...) {
+		events = append(events, newEvent(RankChanged, c))
+	}
+	return append(events, matches...)
+}
+
+// matchDevelopers returns a WatchlistMatched event for every watchlist match of developers that isn't part of previous.
+func (w *Watcher) matchDevelopers(t Target, previous, developers []trending.Developer, now time.Time) []Event {
+	if w.opts.Watchlist == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(previous))
+	for _, d := range previous {
+		seen[strings.ToLower(d.DisplayName)] = true
+	}
+
+	var events []Event
+	for _, m := range w.opts.Watchlist.MatchDevelopers(t.Query, developers) {
+		if seen[strings.ToLower(m.Developer.DisplayName)] {
+			continue
+		}
+		m := m
+		events = append(events, Event{
+			Type:      WatchlistMatched,
+			Kind:      KindDevelopers,
+			Query:     t.Query,
+			Time:      now,
+			Developer: m.Developer,
+			NewRank:   m.Rank,
+			Match:     &m,
+		})
+	}
+	return events
+}
+
+// nextInterval returns the interval until the next poll including jitter.
+func (w *Watcher) nextInterval() time.Duration {
+	interval := w.opts.Interval
+	if w.opts.Jitter <= 0 {
+		return interval
+	}
+
+	// Random factor in [-Jitter, +Jitter)
+	factor := (rand.Float64()*2 - 1) * w.opts.Jitter
+	return interval + time.Duration(float64(interval)*factor)
+}
@@ -0,0 +1,273 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/watchlist"
+)
+
+// fixtureServer is a test HTTP server that answers like github.com.
+// It serves the HTML fixtures while available is true and empty pages otherwise.
+type fixtureServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	available bool
+}
+
+// newFixtureServer starts a fixtureServer that serves the HTML fixtures.
+func newFixtureServer(t *testing.T) *fixtureServer {
+	t.Helper()
+
+	s := &fixtureServer{available: true}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/trending", func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, r, "../testdata/github.com_trending.html")
+	})
+	mux.HandleFunc("/trending/developers", func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, r, "../testdata/github.com_trending_developers.html")
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fixtureServer) serve(w http.ResponseWriter, r *http.Request, file string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.available {
+		http.ServeFile(w, r, file)
+	}
+}
+
+// setAvailable switches between the HTML fixtures and empty pages.
+func (s *fixtureServer) setAvailable(available bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.available = available
+}
+
+// client returns a trending client that talks to the server.
+func (s *fixtureServer) client() *trending.Trending {
+	client := trending.NewTrending()
+	client.BaseURL, _ = url.Parse(s.URL)
+	return client
+}
+
+// countEvents returns the number of events per type.
+func countEvents(events []Event) map[EventType]int {
+	counts := make(map[EventType]int)
+	for _, e := range events {
+		counts[e.Type]++
+	}
+	return counts
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	server := newFixtureServer(t)
+	projects := Target{Query: trending.Query{Since: trending.TimeToday, Language: "go"}}
+	developers := Target{Query: trending.Query{Since: trending.TimeToday}, Developers: true}
+	w := New(server.client(), []Target{projects, developers}, Options{})
+	ctx := context.Background()
+
+	if events := w.Poll(ctx); len(events) != 0 {
+		t.Errorf("First Poll returned %d events, want 0 (baseline)", len(events))
+	}
+	if events := w.Poll(ctx); len(events) != 0 {
+		t.Errorf("Poll without changes returned %d events, want 0", len(events))
+	}
+
+	server.setAvailable(false)
+	events := w.Poll(ctx)
+	if got := countEvents(events); got[Left] != 50 || len(got) != 1 {
+		t.Errorf("Poll of empty pages returned %v, want 50 left events (25 projects and 25 developers)", got)
+	}
+
+	server.setAvailable(true)
+	events = w.Poll(ctx)
+	if got := countEvents(events); got[Entered] != 50 || len(got) != 1 {
+		t.Errorf("Poll after empty pages returned %v, want 50 entered events", got)
+	}
+
+	e := events[0]
+	if e.Kind != KindProjects || e.Query != projects.Query || e.NewRank != 1 || e.OldRank != 0 || e.Project == nil || e.Name() == "" {
+		t.Errorf("Poll returned %+v as first event, want an entered project at rank 1", e)
+	}
+	last := events[len(events)-1]
+	if last.Kind != KindDevelopers || last.Developer == nil || last.NewRank != 25 {
+		t.Errorf("Poll returned %+v as last event, want an entered developer at rank 25", last)
+	}
+}
+
+func TestWatcher_PollStarsAndRanks(t *testing.T) {
+	target := Target{Query: trending.Query{Since: trending.TimeToday}}
+	w := New(nil, []Target{target}, Options{StarsThreshold: 50})
+
+	w.projects[target] = []trending.Project{
+		{Name: "a/one", Stars: 100},
+		{Name: "b/two", Stars: 100},
+	}
+	projects := []trending.Project{
+		{Name: "b/two", Stars: 160},
+		{Name: "a/one", Stars: 120},
+	}
+
+	events := w.compareProjects(target, projects, time.Now())
+	got := countEvents(events)
+	want := map[EventType]int{RankChanged: 2, StarsJumped: 1}
+	if len(got) != len(want) || got[RankChanged] != 2 || got[StarsJumped] != 1 {
+		t.Errorf("compareProjects returned %v, want %v", got, want)
+	}
+}
+
+func TestWatcher_Watchlist(t *testing.T) {
+	target := Target{Query: trending.Query{Since: trending.TimeToday}}
+	w := New(nil, []Target{target}, Options{Watchlist: &watchlist.Watchlist{
+		Repositories: []string{"a/one"},
+		Owners:       []string{"b*"},
+		Developers:   []string{"Alice"},
+	}})
+
+	projects := []trending.Project{
+		{Name: "a/one", Owner: "a", Stars: 100},
+		{Name: "c/three", Owner: "c", Stars: 50},
+	}
+	events := w.compareProjects(target, projects, time.Now())
+	if len(events) != 1 || events[0].Type != WatchlistMatched || events[0].Name() != "a/one" || events[0].NewRank != 1 || events[0].NewStars != 100 {
+		t.Fatalf("compareProjects of the baseline returned %+v, want a match of a/one", events)
+	}
+	if m := events[0].Match; m == nil || m.Rule != watchlist.RuleRepository || m.Pattern != "a/one" || m.Query != target.Query {
+		t.Errorf("compareProjects returned match %+v, want the repository rule", m)
+	}
+
+	// a/one is already known, b/two is new on the page
+	projects = append(projects, trending.Project{Name: "b/two", Owner: "bob", Stars: 10})
+	events = w.compareProjects(target, projects, time.Now())
+	if got := countEvents(events); got[WatchlistMatched] != 1 || got[Entered] != 1 {
+		t.Errorf("compareProjects returned %v, want 1 entered and 1 watchlist_matched event", got)
+	}
+	if last := events[len(events)-1]; last.Type != WatchlistMatched || last.Name() != "b/two" || last.NewRank != 3 {
+		t.Errorf("compareProjects returned %+v as last event, want a match of b/two at rank 3", last)
+	}
+
+	developers := Target{Query: target.Query, Developers: true}
+	events = w.compareDevelopers(developers, []trending.Developer{{DisplayName: "bob"}, {DisplayName: "alice"}}, time.Now())
+	if len(events) != 1 || events[0].Kind != KindDevelopers || events[0].Name() != "alice" || events[0].NewRank != 2 {
+		t.Errorf("compareDevelopers returned %+v, want a match of alice at rank 2", events)
+	}
+}
+
+func TestWatcher_ErrorHandler(t *testing.T) {
+	var gotErr error
+	client := trending.NewTrending()
+	client.BaseURL, _ = url.Parse("http://127.0.0.1:1")
+	w := New(client, []Target{{}}, Options{
+		ErrorHandler: func(t Target, err error) {
+			gotErr = err
+		},
+	})
+
+	if events := w.Poll(context.Background()); len(events) != 0 {
+		t.Errorf("Poll returned %d events, want 0", len(events))
+	}
+	if gotErr == nil {
+		t.Error("ErrorHandler was not called for an unreachable server")
+	}
+}
+
+func TestWatcher_Events(t *testing.T) {
+	server := newFixtureServer(t)
+	w := New(server.client(), []Target{{Query: trending.Query{Since: trending.TimeToday}}}, Options{
+		Interval: 10 * time.Millisecond,
+		Jitter:   0.5,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The baseline is taken immediately, afterwards the page is gone
+	time.AfterFunc(50*time.Millisecond, func() { server.setAvailable(false) })
+
+	var events []Event
+	for e := range w.Events(ctx) {
+		events = append(events, e)
+		if len(events) == 25 {
+			cancel()
+		}
+	}
+
+	if got := countEvents(events); got[Left] != 25 {
+		t.Errorf("Events returned %v, want 25 left events", got)
+	}
+}
+
+func TestWatcher_RunStopsOnCancel(t *testing.T) {
+	server := newFixtureServer(t)
+	w := New(server.client(), []Target{{}}, Options{Interval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx, func(Event) {})
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run returned %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Run did not return after the context was canceled")
+	}
+}
+
+func TestWatcher_NextInterval(t *testing.T) {
+	w := New(nil, nil, Options{Interval: time.Minute, Jitter: 0.1})
+
+	for i := 0; i < 100; i++ {
+		got := w.nextInterval()
+		if got < 54*time.Second || got > 66*time.Second {
+			t.Fatalf("nextInterval returned %v, want between 54s and 66s", got)
+		}
+	}
+}
+
+func TestWatcher_NextIntervalLimitsJitter(t *testing.T) {
+	w := New(nil, nil, Options{Interval: time.Minute, Jitter: 5})
+
+	for i := 0; i < 100; i++ {
+		if got := w.nextInterval(); got < 6*time.Second {
+			t.Fatalf("nextInterval returned %v with a jitter of 5, want at least 6s", got)
+		}
+	}
+}
+
+func TestWatcher_PollCanceled(t *testing.T) {
+	// The server hangs until the request is canceled
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := trending.NewTrending()
+	client.BaseURL, _ = url.Parse(server.URL)
+	w := New(client, []Target{{}}, Options{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	w.Poll(ctx)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Poll returned %v after the context was canceled, want it to abort the request", elapsed)
+	}
+}
@@ -0,0 +1,193 @@
+// Package watchlist alerts when specific repositories, owners, topics or developers appear on trending.
+//
+// A Watchlist is usually loaded from a YAML (or JSON) file:
+//
+//	repositories:
+//	  - andygrunwald/go-trending
+//	owners:
+//	  - andygrunwald
+//	  - "kubernetes*"
+//	keywords:
+//	  - trending
+//	developers:
+//	  - andygrunwald
+//
+// It is matched against the result of a query:
+//
+//	wl, err := watchlist.Load("watchlist.yaml")
+//	if err != nil {
+//		...
+//	}
+//	projects, err := trend.GetProjectsByQuery(q)
+//	...
+//	for _, m := range wl.MatchProjects(q, projects) {
+//		fmt.Printf("%s is trending at rank %d (%s %q)\n", m.Name(), m.Rank, m.Rule, m.Pattern)
+//	}
+//
+// Repositories, owners and developers are compared regardless of case, because GitHub logins
+// and repository names are, and keywords match the description in any case.
+// The watch package emits a watch.WatchlistMatched event for every match, see watch.Options.Watchlist.
+package watchlist
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/andygrunwald/go-trending"
+	"github.com/andygrunwald/go-trending/internal/rulefile"
+)
+
+// Rule is the kind of entry of a Watchlist that matched.
+type Rule string
+
+// Rules of a watchlist.
+const (
+	// RuleRepository matches the full name of a project like "owner/repository".
+	RuleRepository Rule = "repository"
+	// RuleOwner matches the owner of a project with a glob like "kubernetes*".
+	RuleOwner Rule = "owner"
+	// RuleKeyword matches a keyword within the description of a project.
+	RuleKeyword Rule = "keyword"
+	// RuleDeveloper matches the login of a trending developer.
+	RuleDeveloper Rule = "developer"
+)
+
+// Watchlist is a list of repositories, owners, keywords and developers to look out for.
+type Watchlist struct {
+	// Repositories are full names of projects like "andygrunwald/go-trending".
+	Repositories []string `yaml:"repositories" json:"repositories"`
+
+	// Owners are globs of owners of projects like "kubernetes*", see path.Match for the syntax.
+	Owners []string `yaml:"owners" json:"owners"`
+
+	// Keywords are words that are looked for in the description of projects.
+	Keywords []string `yaml:"keywords" json:"keywords"`
+
+	// Developers are logins of developers like "andygrunwald".
+	Developers []string `yaml:"developers" json:"developers"`
+}
+
+// Match is a single project or developer of a trending result that matched a Watchlist.
+type Match struct {
+	// Rule is the kind of entry that matched.
+	Rule Rule `json:"rule"`
+
+	// Pattern is the entry of the Watchlist that matched, as written in the watchlist.
+	Pattern string `json:"pattern"`
+
+	// Query is the query of the trending result.
+	Query trending.Query `json:"query"`
+
+	// Rank is the 1-based position on the trending page.
+	Rank int `json:"rank"`
+
+	// Stars are the stars of the project within the period of the query (trending.Project.PeriodStars). 0 for developers.
+	Stars int `json:"stars,omitempty"`
+
+	// Project is the matched project, if the rule is a project rule.
+	Project *trending.Project `json:"project,omitempty"`
+
+	// Developer is the matched developer, if Rule is RuleDeveloper.
+	Developer *trending.Developer `json:"developer,omitempty"`
+}
+
+// Name returns the name of the project or the display name of the developer of the match.
+func (m Match) Name() string {
+	switch {
+	case m.Project != nil:
+		return m.Project.Name
+	case m.Developer != nil:
+		return m.Developer.DisplayName
+	}
+	return ""
+}
+
+// Load reads the watchlist from the YAML or JSON file name.
+func Load(name string) (*Watchlist, error) {
+	var w Watchlist
+	if err := rulefile.Load(name, "watchlist", &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Parse decodes a watchlist from YAML or JSON and validates it.
+// An empty input is an empty watchlist. Keys other than repositories, owners, keywords and developers are an error.
+func Parse(b []byte) (*Watchlist, error) {
+	var w Watchlist
+	if err := rulefile.Parse(b, "watchlist", &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Validate checks that all owner globs are valid.
+func (w *Watchlist) Validate() error {
+	for _, o := range w.Owners {
+		if _, err := path.Match(o, ""); err != nil {
+			return fmt.Errorf("watchlist: invalid owner glob %q: %w", o, err)
+		}
+	}
+	return nil
+}
+
+// Empty reports whether w has no entries and thus never matches.
+func (w *Watchlist) Empty() bool {
+	return len(w.Repositories) == 0 && len(w.Owners) == 0 && len(w.Keywords) == 0 && len(w.Developers) == 0
+}
+
+// MatchProjects returns the matches of projects, the result of q, in the order of the trending page.
+// A project matching several entries is reported once per entry.
+func (w *Watchlist) MatchProjects(q trending.Query, projects []trending.Project) []Match {
+	var matches []Match
+	for i, p := range projects {
+		for _, m := range w.matchProject(p) {
+			p := p
+			m.Query = q
+			m.Rank = i + 1
+			m.Stars = p.PeriodStars
+			m.Project = &p
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// MatchDevelopers returns the matches of developers, the result of q, in the order of the trending page.
+func (w *Watchlist) MatchDevelopers(q trending.Query, developers []trending.Developer) []Match {
+	var matches []Match
+	for i, d := range developers {
+		for _, login := range w.Developers {
+			if strings.EqualFold(strings.TrimSpace(login), d.DisplayName) {
+				d := d
+				matches = append(matches, Match{Rule: RuleDeveloper, Pattern: login, Query: q, Rank: i + 1, Developer: &d})
+			}
+		}
+	}
+	return matches
+}
+
+// matchProject returns the rules and patterns matching p, without the context of the result.
+func (w *Watchlist) matchProject(p trending.Project) []Match {
+	var matches []Match
+	for _, r := range w.Repositories {
+		if strings.EqualFold(strings.TrimSpace(r), p.Name) {
+			matches = append(matches, Match{Rule: RuleRepository, Pattern: r})
+		}
+	}
+	owner := strings.ToLower(p.Owner)
+	for _, o := range w.Owners {
+		// Validate rejected invalid globs, so the error can be ignored
+		if ok, _ := path.Match(strings.ToLower(strings.TrimSpace(o)), owner); ok {
+			matches = append(matches, Match{Rule: RuleOwner, Pattern: o})
+		}
+	}
+	description := strings.ToLower(p.Description)
+	for _, k := range w.Keywords {
+		if k = strings.TrimSpace(k); len(k) > 0 && strings.Contains(description, strings.ToLower(k)) {
+			matches = append(matches, Match{Rule: RuleKeyword, Pattern: k})
+		}
+	}
+	return matches
+}
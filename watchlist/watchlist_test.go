@@ -0,0 +1,131 @@
+package watchlist
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-trending"
+)
+
+func TestParse(t *testing.T) {
+	w, err := Parse([]byte(`
+repositories:
+  - andygrunwald/go-trending
+owners:
+  - "kubernetes*"
+keywords: [llm]
+developers: [andygrunwald]
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	want := &Watchlist{
+		Repositories: []string{"andygrunwald/go-trending"},
+		Owners:       []string{"kubernetes*"},
+		Keywords:     []string{"llm"},
+		Developers:   []string{"andygrunwald"},
+	}
+	if !reflect.DeepEqual(w, want) {
+		t.Errorf("Parse returned %+v, want %+v", w, want)
+	}
+
+	// JSON is valid YAML
+	if w, err := Parse([]byte(`{"owners": ["golang"]}`)); err != nil || len(w.Owners) != 1 {
+		t.Errorf("Parse of JSON returned %+v, %v, want one owner", w, err)
+	}
+
+	if w, err := Parse(nil); err != nil || !w.Empty() {
+		t.Errorf("Parse of an empty file returned %+v, %v, want an empty watchlist", w, err)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "owner: [golang]", want: "field owner not found"},
+		{input: "owners: golang", want: "cannot unmarshal"},
+		{input: "owners: ['[a-']", want: `invalid owner glob "[a-"`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.input))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) returned error %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "watchlist.yaml")
+	if err := os.WriteFile(name, []byte("repositories: [a/b]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := Load(name)
+	if err != nil || !reflect.DeepEqual(w.Repositories, []string{"a/b"}) {
+		t.Errorf("Load returned %+v, %v, want repository a/b", w, err)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Load of a missing file returned no error")
+	}
+}
+
+func TestWatchlist_MatchProjects(t *testing.T) {
+	w := &Watchlist{
+		Repositories: []string{"AndyGrunwald/go-trending"},
+		Owners:       []string{"kubernetes*", "andygrunwald"},
+		Keywords:     []string{"LLM", " "},
+	}
+	q := trending.Query{Since: trending.TimeWeek, Language: "go"}
+	projects := []trending.Project{
+		{Name: "golang/go", Owner: "golang", Description: "The Go programming language", Stars: 80000, PeriodStars: 300},
+		{Name: "andygrunwald/go-trending", Owner: "andygrunwald", Description: "Go library", Stars: 80000, PeriodStars: 200},
+		{Name: "kubernetes-sigs/kind", Owner: "kubernetes-sigs", Description: "Local clusters for testing an llm", Stars: 80000, PeriodStars: 100},
+	}
+
+	matches := w.MatchProjects(q, projects)
+
+	type result struct {
+		name    string
+		rule    Rule
+		pattern string
+		rank    int
+		stars   int
+	}
+	var got []result
+	for _, m := range matches {
+		if m.Query != q {
+			t.Errorf("MatchProjects returned query %+v, want %+v", m.Query, q)
+		}
+		got = append(got, result{m.Name(), m.Rule, m.Pattern, m.Rank, m.Stars})
+	}
+	want := []result{
+		{"andygrunwald/go-trending", RuleRepository, "AndyGrunwald/go-trending", 2, 200},
+		{"andygrunwald/go-trending", RuleOwner, "andygrunwald", 2, 200},
+		{"kubernetes-sigs/kind", RuleOwner, "kubernetes*", 3, 100},
+		{"kubernetes-sigs/kind", RuleKeyword, "LLM", 3, 100},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchProjects returned %v, want %v", got, want)
+	}
+}
+
+func TestWatchlist_MatchDevelopers(t *testing.T) {
+	w := &Watchlist{Developers: []string{"Alice"}}
+	q := trending.Query{Since: trending.TimeToday}
+
+	matches := w.MatchDevelopers(q, []trending.Developer{{DisplayName: "bob"}, {DisplayName: "alice"}})
+	if len(matches) != 1 {
+		t.Fatalf("MatchDevelopers returned %d matches, want 1", len(matches))
+	}
+	m := matches[0]
+	if m.Rule != RuleDeveloper || m.Name() != "alice" || m.Rank != 2 || m.Project != nil {
+		t.Errorf("MatchDevelopers returned %+v, want alice at rank 2", m)
+	}
+}